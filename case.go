@@ -0,0 +1,176 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseConverter converts strings between case styles, keeping configured initialisms like "ID" or "URL"
+// in uppercase in ToCamel, ToPascal, ToTrain and ToTitle
+type CaseConverter struct {
+	initialisms map[string]string // lowercase word to its initialism form, "id" -> "ID"
+}
+
+// defaultCaseConverter is used by package-level case functions
+var defaultCaseConverter = NewCaseConverter(DefaultInitialisms()) //nolint:gochecknoglobals // read-only
+
+// DefaultInitialisms returns initialisms used by golint, like "ID", "HTTP" and "URL"
+func DefaultInitialisms() []string {
+	return []string{"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP",
+		"JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID",
+		"UUID", "URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS"}
+}
+
+// NewCaseConverter makes a CaseConverter with the given initialisms, matched case-insensitively
+// against whole words and written as given, e.g. "ID" or "OAuth". Nil means no initialisms.
+func NewCaseConverter(initialisms []string) *CaseConverter {
+	c := &CaseConverter{initialisms: make(map[string]string, len(initialisms))}
+	for _, s := range initialisms {
+		c.initialisms[strings.ToLower(s)] = s
+	}
+	return c
+}
+
+// SplitWords splits s into lowercase words for case conversion. Words are separated by any runes
+// other than letters and digits, by lowercase to uppercase change ("fooBar") and by the end of
+// an uppercase acronym ("HTTPServer" is "http", "server"). Digits stay with the preceding word ("utf8Decode"
+// is "utf8", "decode"), plural acronyms stay whole ("IDs" is "ids") and apostrophes are dropped ("don't" is "dont").
+// An acronym followed by a lowercase word starting with its last letter ends before the word ("GPSsignal" is "gps", "signal").
+func SplitWords(s string) []string {
+	runes := []rune(s)
+	var result []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			result = append(result, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range runes {
+		if r == '\'' || r == '’' {
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if len(word) > 0 && isWordStart(runes, i) {
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return result
+}
+
+// isWordStart checks if runes[i] starts a new word, runes[i-1] being a letter or digit of the current word
+func isWordStart(runes []rune, i int) bool {
+	prev, r := runes[i-1], runes[i]
+	if unicode.IsLower(r) {
+		// acronym followed by a word starting with its last letter, "GPSsignal", unless it is a plural like "GPSs"
+		acronym := unicode.IsUpper(prev) && i >= 2 && unicode.IsUpper(runes[i-2])
+		return acronym && unicode.ToUpper(r) == prev && !isPluralS(runes, i)
+	}
+	if !unicode.IsUpper(r) {
+		return false
+	}
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+	if !unicode.IsUpper(prev) || i+1 >= len(runes) || !unicode.IsLower(runes[i+1]) {
+		return false
+	}
+	// acronym followed by a word, "HTTPServer", unless it is a plural like "IDs"
+	// or the word starts with the same letter, then it is split after r
+	return !isPluralS(runes, i+1) && unicode.ToUpper(runes[i+1]) != r
+}
+
+// isPluralS checks if runes[i] is "s" ending a word, as in "IDs" or "URLsList"
+func isPluralS(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 >= len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// ToCamel converts s to camelCase, "user_id" becomes "userID" with default initialisms
+func ToCamel(s string) string { return defaultCaseConverter.ToCamel(s) }
+
+// ToPascal converts s to PascalCase, "user_id" becomes "UserID" with default initialisms
+func ToPascal(s string) string { return defaultCaseConverter.ToPascal(s) }
+
+// ToSnake converts s to snake_case, "HTTPServer" becomes "http_server"
+func ToSnake(s string) string { return defaultCaseConverter.ToSnake(s) }
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE, "HTTPServer" becomes "HTTP_SERVER"
+func ToScreamingSnake(s string) string { return defaultCaseConverter.ToScreamingSnake(s) }
+
+// ToKebab converts s to kebab-case, "HTTPServer" becomes "http-server"
+func ToKebab(s string) string { return defaultCaseConverter.ToKebab(s) }
+
+// ToTrain converts s to Train-Case, "http_server" becomes "HTTP-Server" with default initialisms
+func ToTrain(s string) string { return defaultCaseConverter.ToTrain(s) }
+
+// ToTitle converts s to Title Case with words separated by spaces, "user_id" becomes "User ID" with default initialisms
+func ToTitle(s string) string { return defaultCaseConverter.ToTitle(s) }
+
+// ToCamel converts s to camelCase, the first word is lowercase even if it is an initialism
+func (c *CaseConverter) ToCamel(s string) string {
+	words := SplitWords(s)
+	for i := 1; i < len(words); i++ {
+		words[i] = c.capitalize(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// ToPascal converts s to PascalCase
+func (c *CaseConverter) ToPascal(s string) string {
+	return c.join(s, "")
+}
+
+// ToSnake converts s to snake_case
+func (c *CaseConverter) ToSnake(s string) string {
+	return strings.Join(SplitWords(s), "_")
+}
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE
+func (c *CaseConverter) ToScreamingSnake(s string) string {
+	return strings.ToUpper(strings.Join(SplitWords(s), "_"))
+}
+
+// ToKebab converts s to kebab-case
+func (c *CaseConverter) ToKebab(s string) string {
+	return strings.Join(SplitWords(s), "-")
+}
+
+// ToTrain converts s to Train-Case
+func (c *CaseConverter) ToTrain(s string) string {
+	return c.join(s, "-")
+}
+
+// ToTitle converts s to Title Case with words separated by spaces
+func (c *CaseConverter) ToTitle(s string) string {
+	return c.join(s, " ")
+}
+
+// join capitalizes all words of s and joins them with sep
+func (c *CaseConverter) join(s, sep string) string {
+	words := SplitWords(s)
+	for i, w := range words {
+		words[i] = c.capitalize(w)
+	}
+	return strings.Join(words, sep)
+}
+
+// capitalize returns initialism form of a lowercase word, including plurals like "ids",
+// or the word with the first rune in title case
+func (c *CaseConverter) capitalize(w string) string {
+	if s, ok := c.initialisms[w]; ok {
+		return s
+	}
+	if base, plural := strings.CutSuffix(w, "s"); plural {
+		if s, ok := c.initialisms[base]; ok {
+			return s + "s"
+		}
+	}
+	r, size := utf8.DecodeRuneInString(w)
+	return string(unicode.ToTitle(r)) + w[size:]
+}
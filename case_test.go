@@ -0,0 +1,88 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"hello", []string{"hello"}},
+		{"helloWorld", []string{"hello", "world"}},
+		{"HelloWorld", []string{"hello", "world"}},
+		{"HTTPServer", []string{"http", "server"}},
+		{"newHTTPServer", []string{"new", "http", "server"}},
+		{"utf8Decode", []string{"utf8", "decode"}},
+		{"HTTP2Server", []string{"http2", "server"}},
+		{"base64url", []string{"base64url"}},
+		{"user_id", []string{"user", "id"}},
+		{"USER_ID", []string{"user", "id"}},
+		{"user-id", []string{"user", "id"}},
+		{"--user  ID--", []string{"user", "id"}},
+		{"userIDs", []string{"user", "ids"}},
+		{"URLsList", []string{"urls", "list"}},
+		{"IDs", []string{"ids"}},
+		{"GPSsignal", []string{"gps", "signal"}},
+		{"CSSselector", []string{"css", "selector"}},
+		{"GPSs", []string{"gpss"}},
+		{"GPSsList", []string{"gpss", "list"}},
+		{"don't stop", []string{"dont", "stop"}},
+		{"ПриветМир", []string{"привет", "мир"}},
+		{"file.name/path", []string{"file", "name", "path"}},
+		{"2fa_code", []string{"2fa", "code"}},
+		{"A", []string{"a"}},
+		{"ABC", []string{"abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, SplitWords(tt.input))
+		})
+	}
+}
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		input                                                string
+		camel, pascal, snake, screaming, kebab, train, title string
+	}{
+		{"user_id", "userID", "UserID", "user_id", "USER_ID", "user-id", "User-ID", "User ID"},
+		{"HTTPServer", "httpServer", "HTTPServer", "http_server", "HTTP_SERVER", "http-server", "HTTP-Server", "HTTP Server"},
+		{"createdAt", "createdAt", "CreatedAt", "created_at", "CREATED_AT", "created-at", "Created-At", "Created At"},
+		{"api-key-url", "apiKeyURL", "APIKeyURL", "api_key_url", "API_KEY_URL", "api-key-url", "API-Key-URL", "API Key URL"},
+		{"utf8Decode", "utf8Decode", "UTF8Decode", "utf8_decode", "UTF8_DECODE", "utf8-decode", "UTF8-Decode", "UTF8 Decode"},
+		{"userIDs", "userIDs", "UserIDs", "user_ids", "USER_IDS", "user-ids", "User-IDs", "User IDs"},
+		{"GPSsignal", "gpsSignal", "GpsSignal", "gps_signal", "GPS_SIGNAL", "gps-signal", "Gps-Signal", "Gps Signal"},
+		{"", "", "", "", "", "", "", ""},
+		{"привет мир", "приветМир", "ПриветМир", "привет_мир", "ПРИВЕТ_МИР", "привет-мир", "Привет-Мир", "Привет Мир"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.camel, ToCamel(tt.input), "camel")
+			assert.Equal(t, tt.pascal, ToPascal(tt.input), "pascal")
+			assert.Equal(t, tt.snake, ToSnake(tt.input), "snake")
+			assert.Equal(t, tt.screaming, ToScreamingSnake(tt.input), "screaming snake")
+			assert.Equal(t, tt.kebab, ToKebab(tt.input), "kebab")
+			assert.Equal(t, tt.train, ToTrain(tt.input), "train")
+			assert.Equal(t, tt.title, ToTitle(tt.input), "title")
+		})
+	}
+}
+
+func TestCaseConverter(t *testing.T) {
+	plain := NewCaseConverter(nil)
+	assert.Equal(t, "UserId", plain.ToPascal("user_id"))
+	assert.Equal(t, "httpServer", plain.ToCamel("HTTPServer"))
+	assert.Equal(t, "Http Server", plain.ToTitle("HTTPServer"))
+
+	custom := NewCaseConverter([]string{"OAuth", "id"})
+	assert.Equal(t, "OAuthToken", custom.ToPascal("oauth_token"))
+	assert.Equal(t, "userOAuthid", custom.ToCamel("user_oauth_id"), "initialism written as given")
+	assert.Equal(t, "oauth_token", custom.ToSnake("oauthToken"))
+	assert.Equal(t, "OAUTH_TOKEN", custom.ToScreamingSnake("oauthToken"))
+	assert.Equal(t, "o-auth-token", custom.ToKebab("OAuthToken"), "splitting doesn't depend on initialisms")
+}
@@ -0,0 +1,169 @@
+package stringutils
+
+// Levenshtein returns edit distance between a and b, the minimal number of single-rune insertions,
+// deletions and substitutions needed to turn a into b. Runes are compared, not bytes.
+func Levenshtein(a, b string) int {
+	return levenshteinRunes([]rune(a), []rune(b))
+}
+
+func levenshteinRunes(ra, rb []rune) int {
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	prev, cur := make([]int, len(ra)+1), make([]int, len(ra)+1)
+	for i := range prev {
+		prev[i] = i
+	}
+	for j := 1; j <= len(rb); j++ {
+		cur[0] = j
+		for i := 1; i <= len(ra); i++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[i] = min(prev[i]+1, cur[i-1]+1, prev[i-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(ra)]
+}
+
+// LevenshteinWithin returns edit distance between a and b if it is not more than maxDist, and true.
+// Otherwise it returns maxDist+1 and false. Only cells of the edit matrix within maxDist of its diagonal are
+// computed and the calculation stops as soon as the distance is known to exceed maxDist,
+// so it is much faster than Levenshtein for long strings and small maxDist.
+func LevenshteinWithin(a, b string, maxDist int) (int, bool) {
+	return levenshteinWithinRunes([]rune(a), []rune(b), maxDist)
+}
+
+func levenshteinWithinRunes(ra, rb []rune, maxDist int) (int, bool) {
+	if maxDist < 0 {
+		return 0, false
+	}
+	if len(ra) > len(rb) {
+		ra, rb = rb, ra
+	}
+	n, m := len(ra), len(rb)
+	over := maxDist + 1
+	if m-n > maxDist {
+		return over, false
+	}
+
+	prev, cur := make([]int, m+1), make([]int, m+1)
+	for j := range prev {
+		prev[j] = min(j, over)
+	}
+	for i := 1; i <= n; i++ {
+		lo, hi := max(1, i-maxDist), min(m, i+maxDist)
+		cur[0] = min(i, over)
+		if lo > 1 {
+			cur[lo-1] = over
+		}
+		rowMin := cur[lo-1]
+		for j := lo; j <= hi; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost, over)
+			rowMin = min(rowMin, cur[j])
+		}
+		if hi < m {
+			cur[hi+1] = over
+		}
+		if rowMin > maxDist {
+			return over, false
+		}
+		prev, cur = cur, prev
+	}
+	if prev[m] > maxDist {
+		return over, false
+	}
+	return prev[m], true
+}
+
+// OSADistance returns optimal string alignment distance between a and b. It is Levenshtein distance which
+// also counts a transposition of two adjacent runes as a single edit, but no substring can be edited twice,
+// so OSADistance("ca", "abc") is 3. Runes are compared, not bytes.
+func OSADistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n, m := len(ra), len(rb)
+	// three rows of the edit matrix, transpositions need the one before the previous
+	prev2, prev, cur := make([]int, m+1), make([]int, m+1), make([]int, m+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= n; i++ {
+		cur[0] = i
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[m]
+}
+
+// DamerauLevenshtein returns Damerau-Levenshtein distance between a and b, the minimal number of single-rune
+// insertions, deletions, substitutions and transpositions of two adjacent runes needed to turn a into b.
+// Unlike OSADistance, a substring can be edited after transposition, so DamerauLevenshtein("ca", "abc") is 2.
+// Runes are compared, not bytes.
+func DamerauLevenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n, m := len(ra), len(rb)
+	inf := n + m
+
+	// d is (n+2)x(m+2) matrix, shifted by one to have a border of inf values
+	d := make([][]int, n+2)
+	for i := range d {
+		d[i] = make([]int, m+2)
+	}
+	d[0][0] = inf
+	for i := 0; i <= n; i++ {
+		d[i+1][0], d[i+1][1] = inf, i
+	}
+	for j := 0; j <= m; j++ {
+		d[0][j+1], d[1][j+1] = inf, j
+	}
+
+	lastRow := make(map[rune]int) // last row of a where a rune was seen
+	for i := 1; i <= n; i++ {
+		lastCol := 0 // last column of b in this row where the rune matched
+		for j := 1; j <= m; j++ {
+			i1, j1 := lastRow[rb[j-1]], lastCol
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+				lastCol = j
+			}
+			d[i+1][j+1] = min(d[i][j]+cost, d[i+1][j]+1, d[i][j+1]+1, d[i1][j1]+(i-i1-1)+1+(j-j1-1))
+		}
+		lastRow[ra[i-1]] = i
+	}
+	return d[n+1][m+1]
+}
+
+// ClosestMatch returns the candidate with the smallest Levenshtein distance to s, if it is not more than maxDist.
+// If several candidates are equally close, the first of them is returned. Returns false if no candidate is close enough,
+// which is useful for "did you mean" suggestions.
+func ClosestMatch(s string, candidates []string, maxDist int) (string, bool) {
+	best, found := "", false
+	for _, c := range candidates {
+		d, ok := LevenshteinWithin(s, c, maxDist)
+		if !ok {
+			continue
+		}
+		best, found = c, true
+		if d == 0 {
+			break
+		}
+		maxDist = d - 1 // only strictly closer candidates can replace the found one
+	}
+	return best, found
+}
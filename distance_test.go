@@ -0,0 +1,117 @@
+package stringutils
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistances(t *testing.T) {
+	tests := []struct {
+		a, b          string
+		lev, osa, dam int
+	}{
+		{"", "", 0, 0, 0},
+		{"", "abc", 3, 3, 3},
+		{"abc", "", 3, 3, 3},
+		{"abc", "abc", 0, 0, 0},
+		{"kitten", "sitting", 3, 3, 3},
+		{"flaw", "lawn", 2, 2, 2},
+		{"ab", "ba", 2, 1, 1},
+		{"ca", "abc", 3, 3, 2},
+		{"a cat", "an act", 3, 2, 2},
+		{"deploy", "delpoy", 2, 1, 1},
+		{"привет", "превет", 1, 1, 1},
+		{"привет", "пирвет", 2, 1, 1},
+		{"👍a", "a👍", 2, 1, 1},
+		{"中文", "文中字", 2, 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"|"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.lev, Levenshtein(tt.a, tt.b), "levenshtein")
+			assert.Equal(t, tt.lev, Levenshtein(tt.b, tt.a), "levenshtein is symmetric")
+			assert.Equal(t, tt.osa, OSADistance(tt.a, tt.b), "osa")
+			assert.Equal(t, tt.dam, DamerauLevenshtein(tt.a, tt.b), "damerau")
+			assert.Equal(t, tt.dam, DamerauLevenshtein(tt.b, tt.a), "damerau is symmetric")
+		})
+	}
+}
+
+func TestDistances_Random(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	word := func() string {
+		b := make([]rune, rnd.IntN(8))
+		for i := range b {
+			b[i] = []rune("abcж")[rnd.IntN(4)]
+		}
+		return string(b)
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := word(), word()
+		lev, osa, dam := Levenshtein(a, b), OSADistance(a, b), DamerauLevenshtein(a, b)
+		assert.LessOrEqual(t, dam, osa, "%q %q", a, b)
+		assert.LessOrEqual(t, osa, lev, "%q %q", a, b)
+		for maxDist := 0; maxDist <= 8; maxDist++ {
+			d, ok := LevenshteinWithin(a, b, maxDist)
+			if lev <= maxDist {
+				assert.True(t, ok, "%q %q %d", a, b, maxDist)
+				assert.Equal(t, lev, d, "%q %q %d", a, b, maxDist)
+				continue
+			}
+			assert.False(t, ok, "%q %q %d", a, b, maxDist)
+			assert.Equal(t, maxDist+1, d)
+		}
+	}
+}
+
+func TestLevenshteinWithin(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		maxDist int
+		want    int
+		ok      bool
+	}{
+		{"kitten", "sitting", 3, 3, true},
+		{"kitten", "sitting", 2, 3, false},
+		{"kitten", "sitting", 0, 1, false},
+		{"abc", "abc", 0, 0, true},
+		{"short", "a much longer string", 3, 4, false},
+		{"abc", "abd", -1, 0, false},
+		{"", "ab", 2, 2, true},
+	}
+	for _, tt := range tests {
+		d, ok := LevenshteinWithin(tt.a, tt.b, tt.maxDist)
+		assert.Equal(t, tt.ok, ok, "%q %q %d", tt.a, tt.b, tt.maxDist)
+		assert.Equal(t, tt.want, d, "%q %q %d", tt.a, tt.b, tt.maxDist)
+	}
+}
+
+func TestClosestMatch(t *testing.T) {
+	commands := []string{"build", "deploy", "delete", "describe", "status"}
+	tests := []struct {
+		name       string
+		s          string
+		candidates []string
+		maxDist    int
+		want       string
+		ok         bool
+	}{
+		{"typo", "deplyo", commands, 2, "deploy", true},
+		{"exact", "status", commands, 2, "status", true},
+		{"too far", "restart", commands, 2, "", false},
+		{"closest wins", "delate", commands, 3, "delete", true},
+		{"tie goes to first", "bac", []string{"bad", "bat", "bac"}, 1, "bac", true},
+		{"tie without exact", "ba", []string{"bad", "bat"}, 1, "bad", true},
+		{"empty candidates", "build", nil, 3, "", false},
+		{"zero distance only", "biuld", commands, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ClosestMatch(tt.s, tt.candidates, tt.maxDist)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
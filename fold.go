@@ -0,0 +1,149 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+)
+
+// FoldMode defines how strings are case folded before comparison by the *Fold functions
+type FoldMode int
+
+const (
+	// FoldNone compares strings exactly, byte by byte
+	FoldNone FoldMode = iota
+	// FoldSimple applies simple Unicode case folding, one rune maps to one rune ("Admin" == "admin").
+	// This is the same equivalence as used by strings.EqualFold.
+	FoldSimple
+	// FoldFull applies full Unicode case folding, one rune may map to several ("straße" == "STRASSE")
+	FoldFull
+	// FoldTurkic applies full Unicode case folding with Turkish and Azerbaijani rules for
+	// dotted and dotless i: "I" folds to "ı" and "İ" folds to "i"
+	FoldTurkic
+)
+
+// FoldString returns s case folded according to mode.
+// The result is intended for comparison only, not for display.
+func FoldString(s string, mode FoldMode) string {
+	switch mode {
+	case FoldSimple:
+		return strings.Map(simpleFold, s)
+	case FoldFull:
+		return cases.Fold().String(s)
+	case FoldTurkic:
+		s = strings.Map(func(r rune) rune {
+			switch r {
+			case 'I':
+				return 'ı'
+			case 'İ':
+				return 'i'
+			}
+			return r
+		}, s)
+		return cases.Fold().String(s)
+	default:
+		return s
+	}
+}
+
+// EqualFold checks if a and b are equal after case folding according to mode
+func EqualFold(a, b string, mode FoldMode) bool {
+	if mode == FoldSimple {
+		return strings.EqualFold(a, b)
+	}
+	return FoldString(a, mode) == FoldString(b, mode)
+}
+
+// ContainsFold checks if slice contains a string equal to src after case folding
+func ContainsFold(src string, inSlice []string, mode FoldMode) bool {
+	return IndexOfFold(inSlice, src, mode) >= 0
+}
+
+// ContainsAnySubstringFold checks if string contains any of provided substrings after case folding.
+// Empty substrings are skipped, same as in ContainsAnySubstring. For repeated checks against the same
+// substrings compile them once with NewMatcherFold and use Matcher.Match.
+func ContainsAnySubstringFold(s string, subStrings []string, mode FoldMode) bool {
+	if len(subStrings) == 0 {
+		return false
+	}
+	s = FoldString(s, mode)
+	for _, sub := range subStrings {
+		if sub == "" {
+			continue // skip empty substrings
+		}
+		if strings.Contains(s, FoldString(sub, mode)) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPrefixSliceFold checks if any string in the slice starts with the given prefix after case folding
+func HasPrefixSliceFold(prefix string, slice []string, mode FoldMode) bool {
+	prefix = FoldString(prefix, mode)
+	for _, v := range slice {
+		if strings.HasPrefix(FoldString(v, mode), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSuffixSliceFold checks if any string in the slice ends with the given suffix after case folding
+func HasSuffixSliceFold(suffix string, slice []string, mode FoldMode) bool {
+	suffix = FoldString(suffix, mode)
+	for _, v := range slice {
+		if strings.HasSuffix(FoldString(v, mode), suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IndexOfFold returns the index of the first element equal to element after case folding, or -1 if not found
+func IndexOfFold(slice []string, element string, mode FoldMode) int {
+	element = FoldString(element, mode)
+	for i, s := range slice {
+		if FoldString(s, mode) == element {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexOfFold returns the index of the last element equal to element after case folding, or -1 if not found
+func LastIndexOfFold(slice []string, element string, mode FoldMode) int {
+	element = FoldString(element, mode)
+	for i := len(slice) - 1; i >= 0; i-- {
+		if FoldString(slice[i], mode) == element {
+			return i
+		}
+	}
+	return -1
+}
+
+// simpleFold maps r to a canonical member of its simple case folding orbit, see unicode.SimpleFold.
+// The lowercase form is preferred if it belongs to the orbit, so "K" (Kelvin sign), "K" and "k" all map to "k".
+func simpleFold(r rune) rune {
+	if r < 0x80 {
+		if 'A' <= r && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}
+	lowest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		lowest = min(lowest, f)
+	}
+	lower := unicode.ToLower(lowest)
+	for f := unicode.SimpleFold(r); lower != lowest; f = unicode.SimpleFold(f) {
+		if f == lower {
+			return lower
+		}
+		if f == r {
+			break
+		}
+	}
+	return lowest
+}
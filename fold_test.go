@@ -0,0 +1,126 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldString(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		mode FoldMode
+		want string
+	}{
+		{"none keeps string", "Admin", FoldNone, "Admin"},
+		{"simple ascii", "Admin", FoldSimple, "admin"},
+		{"simple kelvin sign", "Kelvin", FoldSimple, "kelvin"},
+		{"simple long s", "ſtop", FoldSimple, "stop"},
+		{"simple sigma", "ΣΑΣ", FoldSimple, "σασ"},
+		{"simple keeps sharp s", "Straße", FoldSimple, "straße"},
+		{"simple dotless i untouched", "ı", FoldSimple, "ı"},
+		{"full sharp s", "Straße", FoldFull, "strasse"},
+		{"full ligature", "ﬁle", FoldFull, "file"},
+		{"full dotted capital i", "İ", FoldFull, "i̇"},
+		{"turkic capital i", "DIŞ", FoldTurkic, "dış"},
+		{"turkic dotted capital i", "İstanbul", FoldTurkic, "istanbul"},
+		{"turkic sharp s", "Straße", FoldTurkic, "strasse"},
+		{"empty", "", FoldFull, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FoldString(tt.s, tt.mode))
+		})
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		mode FoldMode
+		want bool
+	}{
+		{"none exact", "admin", "admin", FoldNone, true},
+		{"none different case", "Admin", "admin", FoldNone, false},
+		{"simple different case", "Admin", "admin", FoldSimple, true},
+		{"simple sharp s vs ss", "straße", "STRASSE", FoldSimple, false},
+		{"full sharp s vs ss", "straße", "STRASSE", FoldFull, true},
+		{"full capital sharp s", "STRAẞE", "strasse", FoldFull, true},
+		{"full cyrillic", "Привет", "пРИВЕТ", FoldFull, true},
+		{"full dotless i differs", "ı", "I", FoldFull, false},
+		{"turkic dotless i", "ı", "I", FoldTurkic, true},
+		{"turkic dotted i", "i", "İ", FoldTurkic, true},
+		{"turkic i vs I differ", "i", "I", FoldTurkic, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, EqualFold(tt.a, tt.b, tt.mode))
+		})
+	}
+}
+
+func TestSliceFoldFunctions(t *testing.T) {
+	slice := []string{"Admin", "USER", "Straße", "user"}
+
+	t.Run("contains", func(t *testing.T) {
+		assert.True(t, ContainsFold("admin", slice, FoldSimple))
+		assert.False(t, ContainsFold("admin", slice, FoldNone))
+		assert.False(t, ContainsFold("strasse", slice, FoldSimple))
+		assert.True(t, ContainsFold("STRASSE", slice, FoldFull))
+		assert.False(t, ContainsFold("guest", slice, FoldFull))
+		assert.False(t, ContainsFold("admin", nil, FoldFull))
+	})
+
+	t.Run("index of", func(t *testing.T) {
+		assert.Equal(t, 1, IndexOfFold(slice, "user", FoldSimple))
+		assert.Equal(t, 3, IndexOfFold(slice, "user", FoldNone))
+		assert.Equal(t, 2, IndexOfFold(slice, "strasse", FoldFull))
+		assert.Equal(t, -1, IndexOfFold(slice, "guest", FoldFull))
+	})
+
+	t.Run("last index of", func(t *testing.T) {
+		assert.Equal(t, 3, LastIndexOfFold(slice, "User", FoldSimple))
+		assert.Equal(t, 1, LastIndexOfFold(slice, "USER", FoldNone))
+		assert.Equal(t, -1, LastIndexOfFold(slice, "guest", FoldFull))
+		assert.Equal(t, -1, LastIndexOfFold(nil, "guest", FoldFull))
+	})
+
+	t.Run("prefix and suffix", func(t *testing.T) {
+		assert.True(t, HasPrefixSliceFold("STR", slice, FoldSimple))
+		assert.False(t, HasPrefixSliceFold("STR", slice, FoldNone))
+		assert.True(t, HasSuffixSliceFold("SSE", slice, FoldFull))
+		assert.False(t, HasSuffixSliceFold("SSE", slice, FoldSimple))
+		assert.True(t, HasSuffixSliceFold("", slice, FoldFull))
+		assert.False(t, HasPrefixSliceFold("x", nil, FoldFull))
+	})
+
+	t.Run("any substring", func(t *testing.T) {
+		assert.True(t, ContainsAnySubstringFold("Hello WORLD", []string{"world"}, FoldSimple))
+		assert.False(t, ContainsAnySubstringFold("Hello WORLD", []string{"world"}, FoldNone))
+		assert.True(t, ContainsAnySubstringFold("Große Straße", []string{"", "STRASSE"}, FoldFull))
+		assert.True(t, ContainsAnySubstringFold("KIRMIZI", []string{"kırmızı"}, FoldTurkic))
+		assert.False(t, ContainsAnySubstringFold("KIRMIZI", []string{"kırmızı"}, FoldFull))
+		assert.False(t, ContainsAnySubstringFold("hello", []string{""}, FoldFull))
+		assert.False(t, ContainsAnySubstringFold("hello", nil, FoldFull))
+	})
+
+	t.Run("matcher", func(t *testing.T) {
+		m := NewMatcherFold([]string{"STRASSE", "", "world", "ǅ"}, FoldFull)
+		assert.True(t, m.Match("Große Straße"))
+		assert.True(t, m.Match("Hello WORLD"))
+		assert.False(t, m.Match("hello"))
+		assert.Equal(t, []string{"STRASSE", "world"}, m.MatchedPatterns("straße, World"))
+		assert.Equal(t, []SubstringMatch{{Pattern: "STRASSE", Index: 0, Start: 7, End: 14}}, m.FindAll("große straße"),
+			"offsets in folded string")
+		assert.True(t, m.Match("ǆ"))
+
+		for _, s := range []string{"", "Hello WORLD", "Große Straße", "nothing", "ǲ"} {
+			patterns := []string{"STRASSE", "world", "ǅ"}
+			assert.Equal(t, ContainsAnySubstringFold(s, patterns, FoldFull), NewMatcherFold(patterns, FoldFull).Match(s), "%q", s)
+		}
+	})
+}
@@ -0,0 +1,270 @@
+package stringutils
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// FuzzyIndex finds strings within Levenshtein distance of a query without comparing the query to every string.
+// It is an inverted index of character trigrams: a single edit changes at most three trigrams, so strings within
+// distance k of the query share all but 3k of its trigrams, and only such candidates are compared with the query.
+// The index is immutable after NewFuzzyIndex and safe for concurrent use, except for UnmarshalBinary.
+type FuzzyIndex struct {
+	values []string
+	runes  [][]rune         // runes of values, to avoid conversion on every comparison
+	grams  map[string][]int // indexes of values containing the gram, ascending and repeated for each occurrence
+	byLen  [][]int          // indexes of values by length in runes
+}
+
+// FuzzyHit is a string found by FuzzyIndex, with its index in the original slice and distance to the query
+type FuzzyHit struct {
+	Value    string
+	Index    int
+	Distance int
+}
+
+// fuzzyIndexData is serialized form of FuzzyIndex
+type fuzzyIndexData struct {
+	Version int
+	Values  []string
+	Grams   map[string][]int
+}
+
+const fuzzyIndexVersion = 1
+
+// NewFuzzyIndex makes index of values. Duplicates are kept, each with its own index.
+// Values are copied, so later changes of the slice don't affect the index.
+func NewFuzzyIndex(values []string) *FuzzyIndex {
+	f := &FuzzyIndex{values: slices.Clone(values), grams: make(map[string][]int)}
+	f.makeRunes()
+	for i, r := range f.runes {
+		for _, g := range fuzzyGrams(r) {
+			f.grams[g] = append(f.grams[g], i)
+		}
+	}
+	return f
+}
+
+// Len returns number of indexed strings
+func (f *FuzzyIndex) Len() int {
+	return len(f.values)
+}
+
+// Within returns all indexed strings with Levenshtein distance to query not more than maxDist,
+// sorted by distance and then by index
+func (f *FuzzyIndex) Within(query string, maxDist int) []FuzzyHit {
+	if len(f.values) == 0 || maxDist < 0 {
+		return nil
+	}
+	q := []rune(query)
+	var result []FuzzyHit
+	check := func(i int) {
+		if d, ok := levenshteinWithinRunes(q, f.runes[i], maxDist); ok {
+			result = append(result, FuzzyHit{Value: f.values[i], Index: i, Distance: d})
+		}
+	}
+
+	if gramsLowerBound(len(q), len(q), 0) <= maxDist {
+		// strings without common grams can be close enough, filter by length only
+		for l := max(len(q)-maxDist, 0); l <= len(q)+maxDist && l < len(f.byLen); l++ {
+			for _, i := range f.byLen[l] {
+				check(i)
+			}
+		}
+		sortHits(result)
+		return result
+	}
+
+	counts, touched := f.commonGrams(q)
+	for _, i := range touched {
+		l := len(f.runes[i])
+		if abs(l-len(q)) <= maxDist && gramsLowerBound(l, len(q), int(counts[i])) <= maxDist {
+			check(i)
+		}
+	}
+	sortHits(result)
+	return result
+}
+
+// Nearest returns up to n indexed strings closest to query by Levenshtein distance, sorted by distance and then
+// by index. Strings equally close as the last returned one but with a bigger index are left out.
+// Strings sharing more trigrams with the query are compared first, and the rest are skipped once they can't be closer.
+// It is fast if the found strings are close to the query, otherwise much of the index has to be compared.
+func (f *FuzzyIndex) Nearest(query string, n int) []FuzzyHit {
+	if len(f.values) == 0 || n <= 0 {
+		return nil
+	}
+	q := []rune(query)
+	var result []FuzzyHit // sorted, at most n
+	// add compares value i with query if its distance lower bound doesn't rule it out
+	add := func(i, lowerBound int) {
+		bound := len(q) + len(f.runes[i])
+		if len(result) == n {
+			bound = result[n-1].Distance
+		}
+		if lowerBound > bound {
+			return
+		}
+		d, ok := levenshteinWithinRunes(q, f.runes[i], bound)
+		if !ok {
+			return
+		}
+		hit := FuzzyHit{Value: f.values[i], Index: i, Distance: d}
+		pos := sort.Search(len(result), func(k int) bool { return hitLess(hit, result[k]) })
+		if pos == n {
+			return
+		}
+		result = append(result, FuzzyHit{})
+		copy(result[pos+1:], result[pos:])
+		result[pos] = hit
+		result = result[:min(len(result), n)]
+	}
+	full := func() bool { return len(result) == n }
+
+	// values with more common grams are likely closer, check them first
+	counts, touched := f.commonGrams(q)
+	sort.Slice(touched, func(a, b int) bool { return counts[touched[a]] > counts[touched[b]] })
+	for _, i := range touched {
+		c := int(counts[i])
+		if full() && gramsLowerBound(len(q), len(q), c) > result[n-1].Distance {
+			break // the rest have no more common grams, so they can't be closer
+		}
+		l := len(f.runes[i])
+		add(i, max(abs(l-len(q)), gramsLowerBound(l, len(q), c)))
+	}
+
+	// values without common grams
+	if full() && gramsLowerBound(len(q), len(q), 0) > result[n-1].Distance {
+		return result
+	}
+	for l, values := range f.byLen {
+		for _, i := range values {
+			if counts[i] == 0 {
+				add(i, max(abs(l-len(q)), gramsLowerBound(l, len(q), 0)))
+			}
+		}
+	}
+	return result
+}
+
+// commonGrams returns number of grams each value shares with q and the values sharing at least one gram.
+// A gram counts as many times as it is present in both strings.
+func (f *FuzzyIndex) commonGrams(q []rune) (counts []int32, touched []int) {
+	counts = make([]int32, len(f.values))
+	queryGrams := make(map[string]int32)
+	for _, g := range fuzzyGrams(q) {
+		queryGrams[g]++
+	}
+	for g, qc := range queryGrams {
+		postings := f.grams[g]
+		for k := 0; k < len(postings); {
+			// postings of the same value are adjacent
+			i, n := postings[k], int32(0)
+			for k < len(postings) && postings[k] == i {
+				k++
+				n++
+			}
+			if counts[i] == 0 {
+				touched = append(touched, i)
+			}
+			counts[i] += min(n, qc)
+		}
+	}
+	return counts, touched
+}
+
+// MarshalBinary encodes the index, so it can be loaded without building again
+func (f *FuzzyIndex) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	data := fuzzyIndexData{Version: fuzzyIndexVersion, Values: f.values, Grams: f.grams}
+	if err := gob.NewEncoder(&buf).Encode(data); err != nil {
+		return nil, fmt.Errorf("failed to encode fuzzy index: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the index encoded by MarshalBinary, replacing the current content
+func (f *FuzzyIndex) UnmarshalBinary(b []byte) error {
+	var data fuzzyIndexData
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&data); err != nil {
+		return fmt.Errorf("failed to decode fuzzy index: %w", err)
+	}
+	if data.Version != fuzzyIndexVersion {
+		return fmt.Errorf("unsupported fuzzy index version %d", data.Version)
+	}
+	for g, postings := range data.Grams {
+		for k, i := range postings {
+			if i < 0 || i >= len(data.Values) || (k > 0 && i < postings[k-1]) {
+				return fmt.Errorf("invalid fuzzy index, bad postings of %q", g)
+			}
+		}
+	}
+	if data.Grams == nil {
+		data.Grams = make(map[string][]int)
+	}
+	f.values, f.grams = data.Values, data.Grams
+	f.makeRunes()
+	return nil
+}
+
+// makeRunes fills runes and byLen from values
+func (f *FuzzyIndex) makeRunes() {
+	f.runes = make([][]rune, len(f.values))
+	f.byLen = nil
+	for i, v := range f.values {
+		f.runes[i] = []rune(v)
+		l := len(f.runes[i])
+		for len(f.byLen) <= l {
+			f.byLen = append(f.byLen, nil)
+		}
+		f.byLen[l] = append(f.byLen[l], i)
+	}
+}
+
+// fuzzyGramSize is the length of grams in FuzzyIndex
+const fuzzyGramSize = 3
+
+// fuzzyGrams returns grams of runes padded with zero runes on both sides, so every string has len+fuzzyGramSize-1 of them
+func fuzzyGrams(r []rune) []string {
+	padded := make([]rune, 0, len(r)+2*(fuzzyGramSize-1))
+	for i := 0; i < fuzzyGramSize-1; i++ {
+		padded = append(padded, 0)
+	}
+	padded = append(padded, r...)
+	for i := 0; i < fuzzyGramSize-1; i++ {
+		padded = append(padded, 0)
+	}
+	result := make([]string, 0, len(r)+fuzzyGramSize-1)
+	for i := 0; i+fuzzyGramSize <= len(padded); i++ {
+		result = append(result, string(padded[i:i+fuzzyGramSize]))
+	}
+	return result
+}
+
+// gramsLowerBound returns the lowest possible distance between strings of lengths la and lb with common grams.
+// A string has len+fuzzyGramSize-1 grams and each edit breaks at most fuzzyGramSize of them.
+func gramsLowerBound(la, lb, common int) int {
+	missing := max(la, lb) + fuzzyGramSize - 1 - common
+	return max((missing+fuzzyGramSize-1)/fuzzyGramSize, 0)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func hitLess(a, b FuzzyHit) bool {
+	if a.Distance != b.Distance {
+		return a.Distance < b.Distance
+	}
+	return a.Index < b.Index
+}
+
+func sortHits(hits []FuzzyHit) {
+	sort.Slice(hits, func(i, j int) bool { return hitLess(hits[i], hits[j]) })
+}
@@ -0,0 +1,162 @@
+package stringutils
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyIndex(t *testing.T) {
+	idx := NewFuzzyIndex([]string{"book", "books", "cake", "boo", "cape", "cart", "boon", "book", "кот"})
+	assert.Equal(t, 9, idx.Len())
+
+	tests := []struct {
+		name    string
+		query   string
+		maxDist int
+		want    []FuzzyHit
+	}{
+		{"exact with duplicate", "book", 0, []FuzzyHit{{"book", 0, 0}, {"book", 7, 0}}},
+		{"within one", "bool", 1, []FuzzyHit{{"book", 0, 1}, {"boo", 3, 1}, {"boon", 6, 1}, {"book", 7, 1}}},
+		{"within two", "cak", 2, []FuzzyHit{{"cake", 2, 1}, {"cape", 4, 2}, {"cart", 5, 2}}},
+		{"unicode", "кит", 1, []FuzzyHit{{"кот", 8, 1}}},
+		{"nothing close", "xyzzy", 2, nil},
+		{"negative distance", "book", -1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, idx.Within(tt.query, tt.maxDist))
+		})
+	}
+
+	assert.Equal(t, []FuzzyHit{{"cake", 2, 1}, {"cape", 4, 2}}, idx.Nearest("cak", 2))
+	assert.Equal(t, []FuzzyHit{{"book", 0, 0}, {"book", 7, 0}, {"books", 1, 1}}, idx.Nearest("book", 3))
+	assert.Len(t, idx.Nearest("zzz", 100), 9, "all values if n is bigger than index")
+	assert.Nil(t, idx.Nearest("book", 0))
+
+	short := NewFuzzyIndex([]string{"", "a", "ab", "abc", "b"})
+	assert.Equal(t, []FuzzyHit{{"", 0, 0}, {"a", 1, 1}, {"b", 4, 1}}, short.Within("", 1))
+	assert.Equal(t, []FuzzyHit{{"a", 1, 0}, {"", 0, 1}, {"ab", 2, 1}, {"b", 4, 1}}, short.Within("a", 1))
+	assert.Equal(t, []FuzzyHit{{"abc", 3, 0}, {"ab", 2, 1}}, short.Nearest("abc", 2))
+
+	empty := NewFuzzyIndex(nil)
+	assert.Nil(t, empty.Within("a", 3))
+	assert.Nil(t, empty.Nearest("a", 3))
+}
+
+func TestFuzzyIndex_ValuesCopied(t *testing.T) {
+	values := []string{"book", "cake"}
+	idx := NewFuzzyIndex(values)
+	values[0] = "changed"
+	assert.Equal(t, []FuzzyHit{{"book", 0, 1}}, idx.Within("bool", 1))
+}
+
+func TestFuzzyIndex_BruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	gen := NewSourceGenerator(rand.NewPCG(3, 4))
+	values := make([]string, 2000)
+	for i := range values {
+		values[i] = gen.RandomWord(3, 8)
+	}
+	idx := NewFuzzyIndex(values)
+
+	brute := func(query string) []FuzzyHit {
+		hits := make([]FuzzyHit, len(values))
+		for i, v := range values {
+			hits[i] = FuzzyHit{Value: v, Index: i, Distance: Levenshtein(query, v)}
+		}
+		sort.SliceStable(hits, func(i, j int) bool { return hits[i].Distance < hits[j].Distance })
+		return hits
+	}
+
+	for i := 0; i < 50; i++ {
+		query := values[rnd.IntN(len(values))][1:] + "a"
+		all := brute(query)
+		for _, k := range []int{0, 1, 2, 3} {
+			var want []FuzzyHit
+			for _, h := range all {
+				if h.Distance <= k {
+					want = append(want, h)
+				}
+			}
+			assert.Equal(t, want, idx.Within(query, k), "%q within %d", query, k)
+		}
+		for _, n := range []int{1, 5, 20} {
+			assert.Equal(t, all[:n], idx.Nearest(query, n), "%q nearest %d", query, n)
+		}
+	}
+}
+
+func TestFuzzyIndex_Marshal(t *testing.T) {
+	values := []string{"alpha", "alpine", "beta", "bet", "gamma", "alpha"}
+	idx := NewFuzzyIndex(values)
+	data, err := idx.MarshalBinary()
+	require.NoError(t, err)
+
+	var loaded FuzzyIndex
+	require.NoError(t, loaded.UnmarshalBinary(data))
+	assert.Equal(t, idx.Len(), loaded.Len())
+	for _, q := range []string{"alpa", "bets", "gama", "zzz"} {
+		assert.Equal(t, idx.Within(q, 2), loaded.Within(q, 2), q)
+		assert.Equal(t, idx.Nearest(q, 3), loaded.Nearest(q, 3), q)
+	}
+
+	data, err = NewFuzzyIndex(nil).MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, loaded.UnmarshalBinary(data))
+	assert.Equal(t, 0, loaded.Len())
+
+	require.Error(t, loaded.UnmarshalBinary([]byte("garbage")))
+}
+
+func TestFuzzyIndex_UnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data fuzzyIndexData
+	}{
+		{"wrong version", fuzzyIndexData{Version: 2}},
+		{"value out of range", fuzzyIndexData{Version: fuzzyIndexVersion, Values: []string{"a"},
+			Grams: map[string][]int{"\x00a": {1}}}},
+		{"negative value", fuzzyIndexData{Version: fuzzyIndexVersion, Values: []string{"a"},
+			Grams: map[string][]int{"\x00a": {-1}}}},
+		{"unsorted postings", fuzzyIndexData{Version: fuzzyIndexVersion, Values: []string{"a", "a"},
+			Grams: map[string][]int{"\x00a": {1, 0}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buf).Encode(tt.data))
+			var loaded FuzzyIndex
+			require.Error(t, loaded.UnmarshalBinary(buf.Bytes()))
+		})
+	}
+}
+
+func TestFuzzyIndex_Concurrent(t *testing.T) {
+	values := make([]string, 500)
+	for i := range values {
+		values[i] = fmt.Sprintf("item-%d", i)
+	}
+	idx := NewFuzzyIndex(values)
+	want := idx.Within("item-42", 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.Equal(t, want, idx.Within("item-42", 1))
+				assert.Len(t, idx.Nearest("item-7", 3), 3)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,209 @@
+package stringutils
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+	"math/rand/v2"
+	"strings"
+	"sync"
+)
+
+// Generator makes random words from an injectable source of randomness.
+// With crypto/rand it produces unpredictable values, with a seeded source it produces the same
+// sequence on every run, which is useful for golden-file tests and reproducible fixtures.
+// Generator is safe for concurrent use, but the sequence is reproducible only if used from a single goroutine.
+type Generator struct {
+	mu         sync.Mutex
+	src        io.Reader
+	blocklist  *Matcher
+	checker    func(word string) bool
+	maxRetries int
+}
+
+const defaultMaxRetries = 100
+
+// NewGenerator makes a Generator reading random bytes from src, crypto/rand.Reader if src is nil
+func NewGenerator(src io.Reader) *Generator {
+	if src == nil {
+		src = crand.Reader
+	}
+	return &Generator{src: src}
+}
+
+// NewSeededGenerator makes a deterministic Generator, same seed produces the same sequence of values.
+// It uses ChaCha8 from math/rand/v2 and must not be used for secrets.
+func NewSeededGenerator(seed uint64) *Generator {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:8], seed)
+	return NewGenerator(rand.NewChaCha8(key))
+}
+
+// NewSourceGenerator makes a Generator drawing random values from math/rand/v2 Source, e.g. rand.NewPCG(1, 2)
+func NewSourceGenerator(src rand.Source) *Generator {
+	return NewGenerator(&sourceReader{src: src})
+}
+
+// RandomWord generates pronounceable random word with length between minLen and maxLen.
+// It panics if the source of randomness fails, or if every attempt is rejected by the blocklist or the checker,
+// e.g. with a checker too strict to accept any word. Use RandomWordE to handle these errors.
+func (g *Generator) RandomWord(minLen, maxLen int) string {
+	w, err := g.RandomWordE(minLen, maxLen)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// RandomWordE generates pronounceable random word with length between minLen and maxLen.
+// Vowels and consonants alternate, the word starts with either of them with equal probability.
+// Returns error if the source of randomness fails, no partially random word is ever returned,
+// or if every attempt was rejected by the blocklist or the checker.
+func (g *Generator) RandomWordE(minLen, maxLen int) (string, error) {
+	return g.filtered(func() (string, error) { return g.randomWord(minLen, maxLen) })
+}
+
+// SetBlocklist makes the generator reject words containing any of the given substrings, same as ContainsAnySubstring,
+// and generate them again. Matching is case-sensitive, generated words are lowercase. Nil or empty list disables it.
+// See DefaultBlocklist for a list of obvious English offenders.
+func (g *Generator) SetBlocklist(substrings []string) {
+	var m *Matcher
+	if len(substrings) > 0 {
+		m = NewMatcher(substrings)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.blocklist = m
+}
+
+// SetChecker sets a function checking every generated word, words for which it returns false are generated again.
+// It is applied in addition to the blocklist, nil disables it.
+func (g *Generator) SetChecker(fn func(word string) bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.checker = fn
+}
+
+// SetMaxRetries sets how many words are generated before giving up if all of them are rejected, 100 by default.
+// Values below 1 reset it to the default.
+func (g *Generator) SetMaxRetries(n int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.maxRetries = n
+}
+
+// DefaultBlocklist returns a small list of obvious English offenders for SetBlocklist, including short
+// forms which pronounceable words can contain, like "fuk" or "tit". The list is matched as substrings, so
+// innocent words containing them, like "titan", are rejected too. Rejection makes the output less random:
+// if a fraction p of words is blocked, each word loses -log2(1-p) bits of entropy. About 1.4% of
+// RandomWord(4, 8) words are blocked, which costs 0.02 bits per word, and about 4% of 10-16 letter words (0.06 bits).
+func DefaultBlocklist() []string {
+	return []string{"fuk", "fuc", "fag", "cum", "tit", "sex", "nig", "nazi", "rape", "anal", "anus", "puta", "pedo",
+		"dik", "kok", "coc", "pis", "poo", "jiz", "wank", "kike", "paki", "slut", "twat", "wop"}
+}
+
+// filtered calls gen until it makes a word passing the blocklist and the checker
+func (g *Generator) filtered(gen func() (string, error)) (string, error) {
+	g.mu.Lock()
+	blocklist, checker, retries := g.blocklist, g.checker, g.maxRetries
+	g.mu.Unlock()
+	if retries < 1 {
+		retries = defaultMaxRetries
+	}
+	for i := 0; i < retries; i++ {
+		w, err := gen()
+		if err != nil {
+			return "", err
+		}
+		if (blocklist == nil || !blocklist.Match(w)) && (checker == nil || checker(w)) {
+			return w, nil
+		}
+	}
+	return "", fmt.Errorf("all %d generated words are blocked", retries)
+}
+
+func (g *Generator) randomWord(minLen, maxLen int) (string, error) {
+	if minLen < 2 {
+		minLen = 2
+	}
+	if maxLen < minLen {
+		maxLen = minLen
+	}
+
+	const vowels, consonants = "aeiou", "bcdfghjklmnpqrstvwxyz"
+
+	// make a random length between min and max
+	n, err := g.intn(maxLen - minLen + 1)
+	if err != nil {
+		return "", err
+	}
+	length := minLen + n
+
+	// decide to start with vowel or consonant
+	if n, err = g.intn(2); err != nil {
+		return "", err
+	}
+	startWithVowel := n == 0
+
+	var result strings.Builder
+	for i := 0; i < length; i++ {
+		letters := consonants
+		if (i%2 == 0) == startWithVowel {
+			letters = vowels
+		}
+		if n, err = g.intn(len(letters)); err != nil {
+			return "", err
+		}
+		result.WriteByte(letters[n])
+	}
+
+	return result.String(), nil
+}
+
+// intn returns uniform random number in [0, n), using rejection sampling to avoid modulo bias
+func (g *Generator) intn(n int) (int, error) {
+	if n <= 1 {
+		return 0, nil
+	}
+	maxVal := uint64(n - 1)
+	size := bits.Len64(maxVal)
+	nbytes := (size + 7) / 8
+	mask := uint64(1)<<size - 1
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(g.src, buf[:nbytes]); err != nil {
+			return 0, fmt.Errorf("failed to read random source: %w", err)
+		}
+		var v uint64
+		for _, b := range buf[:nbytes] {
+			v = v<<8 | uint64(b)
+		}
+		if v &= mask; v <= maxVal {
+			return int(v), nil
+		}
+	}
+}
+
+// sourceReader adapts math/rand/v2 Source to io.Reader
+type sourceReader struct {
+	src rand.Source
+	buf [8]byte
+	n   int // number of unread bytes in buf
+}
+
+func (r *sourceReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.n == 0 {
+			binary.LittleEndian.PutUint64(r.buf[:], r.src.Uint64())
+			r.n = len(r.buf)
+		}
+		p[i] = r.buf[len(r.buf)-r.n]
+		r.n--
+	}
+	return len(p), nil
+}
@@ -0,0 +1,209 @@
+package stringutils
+
+import (
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Seeded(t *testing.T) {
+	gen1, gen2 := NewSeededGenerator(42), NewSeededGenerator(42)
+	var seq1, seq2 []string
+	for i := 0; i < 20; i++ {
+		seq1 = append(seq1, gen1.RandomWord(4, 8))
+		seq2 = append(seq2, gen2.RandomWord(4, 8))
+	}
+	assert.Equal(t, seq1, seq2, "same seed should produce same sequence")
+
+	gen3 := NewSeededGenerator(43)
+	var seq3 []string
+	for i := 0; i < 20; i++ {
+		seq3 = append(seq3, gen3.RandomWord(4, 8))
+	}
+	assert.NotEqual(t, seq1, seq3, "different seeds should produce different sequences")
+
+	// golden values, the sequence for a seed must not change between runs and releases
+	gen := NewSeededGenerator(1)
+	assert.Equal(t, []string{"atesap", "ikohase", "iciq"}, []string{gen.RandomWord(4, 8), gen.RandomWord(4, 8), gen.RandomWord(4, 8)})
+}
+
+func TestGenerator_Source(t *testing.T) {
+	gen1 := NewSourceGenerator(rand.NewPCG(1, 2))
+	gen2 := NewSourceGenerator(rand.NewPCG(1, 2))
+	for i := 0; i < 10; i++ {
+		w := gen1.RandomWord(5, 10)
+		assert.Equal(t, w, gen2.RandomWord(5, 10))
+		assert.GreaterOrEqual(t, len(w), 5)
+		assert.LessOrEqual(t, len(w), 10)
+	}
+}
+
+func TestGenerator_RandomWord(t *testing.T) {
+	gens := map[string]*Generator{
+		"crypto": NewGenerator(nil),
+		"seeded": NewSeededGenerator(7),
+		"pcg":    NewSourceGenerator(rand.NewPCG(3, 4)),
+	}
+	for name, gen := range gens {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				got := gen.RandomWord(1, 6)
+				require.GreaterOrEqual(t, len(got), 2)
+				require.LessOrEqual(t, len(got), 6)
+				prevIsVowel := strings.ContainsRune("aeiou", rune(got[0]))
+				for _, r := range got[1:] {
+					isVowel := strings.ContainsRune("aeiou", r)
+					require.NotEqual(t, prevIsVowel, isVowel, "vowels/consonants should alternate in %q", got)
+					prevIsVowel = isVowel
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_intn(t *testing.T) {
+	// 3 needs 2 bits, value 3 after masking is rejected and the next byte is used
+	gen := NewGenerator(bytes.NewReader([]byte{0xff, 0x06, 0x01}))
+	v, err := gen.intn(3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+	v, err = gen.intn(3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+	_, err = gen.intn(3)
+	require.Error(t, err, "source is exhausted")
+
+	v, err = gen.intn(1)
+	require.NoError(t, err, "no randomness needed for a single value")
+	assert.Equal(t, 0, v)
+
+	// values spanning several bytes
+	gen = NewGenerator(bytes.NewReader([]byte{0x01, 0x02}))
+	v, err = gen.intn(1000)
+	require.NoError(t, err)
+	assert.Equal(t, 0x0102, v)
+
+	// roughly uniform distribution
+	gen = NewSeededGenerator(1)
+	counts := make([]int, 6)
+	for i := 0; i < 6000; i++ {
+		v, err := gen.intn(6)
+		require.NoError(t, err)
+		counts[v]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 1000, c, 150)
+	}
+}
+
+func TestGenerator_RandomWordE(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		w, err := NewSeededGenerator(1).RandomWordE(4, 8)
+		require.NoError(t, err)
+		assert.Equal(t, "atesap", w, "same as RandomWord for the same seed")
+
+		w, err = RandomWordE(3, 5)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(w), 3)
+		assert.LessOrEqual(t, len(w), 5)
+	})
+
+	t.Run("failing source", func(t *testing.T) {
+		gen := NewGenerator(failingReader{err: errEntropy})
+		w, err := gen.RandomWordE(4, 8)
+		require.ErrorIs(t, err, errEntropy)
+		assert.Empty(t, w)
+	})
+
+	t.Run("source failing in the middle of a word", func(t *testing.T) {
+		gen := NewGenerator(bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x00}))
+		w, err := gen.RandomWordE(6, 6)
+		require.Error(t, err)
+		assert.Empty(t, w, "partially random word should not be returned")
+	})
+
+	t.Run("RandomWord panics", func(t *testing.T) {
+		gen := NewGenerator(failingReader{err: errEntropy})
+		assert.PanicsWithError(t, "failed to read random source: entropy failure", func() { gen.RandomWord(4, 8) })
+	})
+}
+
+var errEntropy = errors.New("entropy failure")
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestGenerator_Blocklist(t *testing.T) {
+	gen := NewSeededGenerator(1)
+	gen.SetBlocklist([]string{"a"})
+	for i := 0; i < 50; i++ {
+		w := gen.RandomWord(4, 8)
+		require.NotContains(t, w, "a")
+	}
+
+	gen.SetBlocklist(DefaultBlocklist())
+	for i := 0; i < 2000; i++ {
+		w, err := gen.RandomWordE(4, 8)
+		require.NoError(t, err)
+		require.False(t, ContainsAnySubstring(w, DefaultBlocklist()), w)
+	}
+
+	gen.SetBlocklist(nil)
+	assert.Equal(t, "atesap", NewSeededGenerator(1).RandomWord(4, 8), "no filtering by default")
+}
+
+func TestGenerator_Checker(t *testing.T) {
+	gen := NewSeededGenerator(1)
+	startsWithVowel := func(w string) bool { return strings.ContainsRune("aeiou", rune(w[0])) }
+	gen.SetChecker(startsWithVowel)
+	for i := 0; i < 20; i++ {
+		w, err := gen.RandomWordE(4, 8)
+		require.NoError(t, err)
+		assert.True(t, startsWithVowel(w), w)
+	}
+
+	// checker and blocklist are both applied
+	gen.SetBlocklist([]string{"ba"})
+	w, err := gen.RandomWordE(4, 8)
+	require.NoError(t, err)
+	assert.True(t, startsWithVowel(w), w)
+	assert.NotContains(t, w, "ba")
+
+	// profile words are filtered too
+	gen.SetChecker(func(w string) bool { return !strings.Contains(w, "a") })
+	for i := 0; i < 20; i++ {
+		w, err = gen.ProfileWord(JapaneseProfile(), 4, 8)
+		require.NoError(t, err)
+		assert.NotContains(t, w, "a")
+		assert.NotContains(t, w, "ba")
+	}
+}
+
+func TestGenerator_MaxRetries(t *testing.T) {
+	calls := 0
+	gen := NewSeededGenerator(1)
+	gen.SetChecker(func(string) bool { calls++; return false })
+	_, err := gen.RandomWordE(4, 8)
+	require.Error(t, err)
+	assert.Equal(t, 100, calls)
+
+	calls = 0
+	gen.SetMaxRetries(5)
+	_, err = gen.RandomWordE(4, 8)
+	require.Error(t, err)
+	assert.Equal(t, 5, calls)
+	assert.PanicsWithError(t, "all 5 generated words are blocked", func() { gen.RandomWord(4, 8) },
+		"RandomWord panics if the checker rejects everything")
+
+	calls = 0
+	gen.SetMaxRetries(0)
+	_, err = gen.ProfileWord(EnglishProfile(), 4, 8)
+	require.Error(t, err)
+	assert.Equal(t, 100, calls, "zero resets to default")
+}
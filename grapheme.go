@@ -0,0 +1,281 @@
+package stringutils
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Graphemes splits s into extended grapheme clusters as defined by Unicode Standard Annex #29.
+// A grapheme cluster is what users perceive as a single character, e.g. "é" written as "e" and
+// a combining accent, a flag made of two regional indicators or an emoji ZWJ sequence.
+// The Indic conjunct rule (GB9c) is not applied, so conjuncts are split after the virama.
+func Graphemes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	result := make([]string, 0, len(s))
+	for s != "" {
+		n := nextGrapheme(s)
+		result = append(result, s[:n])
+		s = s[n:]
+	}
+	return result
+}
+
+// GraphemeCount returns number of extended grapheme clusters in s
+func GraphemeCount(s string) int {
+	count := 0
+	for s != "" {
+		s = s[nextGrapheme(s):]
+		count++
+	}
+	return count
+}
+
+// TruncateGraphemes cuts string to the given length (in grapheme clusters) and adds ellipsis if it was truncated.
+// Unlike Truncate it never splits emoji sequences, flags or characters with combining marks.
+// if maxLen is less than 4 (3 chars for ellipsis + 1 grapheme from string), returns empty string
+func TruncateGraphemes(s string, maxLen int) string {
+	return TruncateWith(s, TruncateOptions{MaxLen: maxLen, Unit: UnitGraphemes})
+}
+
+// graphemeProp is a value of Grapheme_Cluster_Break property, with Extended_Pictographic added
+type graphemeProp int
+
+const (
+	gbOther graphemeProp = iota
+	gbCR
+	gbLF
+	gbControl
+	gbExtend
+	gbZWJ
+	gbRegionalIndicator
+	gbPrepend
+	gbSpacingMark
+	gbL
+	gbV
+	gbT
+	gbLV
+	gbLVT
+	gbExtPict
+)
+
+// nextGrapheme returns the length in bytes of the first grapheme cluster in s
+func nextGrapheme(s string) int {
+	r, pos := utf8.DecodeRuneInString(s)
+	prev := graphemePropOf(r)
+	riCount := 0  // number of consecutive regional indicators before the current rune
+	emojiZWJ := 0 // 1 after ExtPict Extend*, 2 after ExtPict Extend* ZWJ
+	if prev == gbRegionalIndicator {
+		riCount = 1
+	}
+	if prev == gbExtPict {
+		emojiZWJ = 1
+	}
+
+	for pos < len(s) {
+		r, size := utf8.DecodeRuneInString(s[pos:])
+		cur := graphemePropOf(r)
+		if graphemeBreak(prev, cur, riCount, emojiZWJ) {
+			break
+		}
+
+		switch {
+		case cur == gbExtPict:
+			emojiZWJ = 1
+		case cur == gbExtend && emojiZWJ == 1: // extend keeps the emoji sequence going
+		case cur == gbZWJ && emojiZWJ == 1:
+			emojiZWJ = 2
+		default:
+			emojiZWJ = 0
+		}
+		if cur == gbRegionalIndicator {
+			riCount++
+		} else {
+			riCount = 0
+		}
+		prev = cur
+		pos += size
+	}
+	return pos
+}
+
+// graphemeBreak applies UAX #29 rules GB3-GB999 to decide if there is a boundary between prev and cur
+func graphemeBreak(prev, cur graphemeProp, riCount, emojiZWJ int) bool {
+	switch {
+	case prev == gbCR && cur == gbLF: // GB3
+		return false
+	case prev == gbControl || prev == gbCR || prev == gbLF: // GB4
+		return true
+	case cur == gbControl || cur == gbCR || cur == gbLF: // GB5
+		return true
+	case prev == gbL && (cur == gbL || cur == gbV || cur == gbLV || cur == gbLVT): // GB6
+		return false
+	case (prev == gbLV || prev == gbV) && (cur == gbV || cur == gbT): // GB7
+		return false
+	case (prev == gbLVT || prev == gbT) && cur == gbT: // GB8
+		return false
+	case cur == gbExtend || cur == gbZWJ: // GB9
+		return false
+	case cur == gbSpacingMark: // GB9a
+		return false
+	case prev == gbPrepend: // GB9b
+		return false
+	case prev == gbZWJ && cur == gbExtPict && emojiZWJ == 2: // GB11
+		return false
+	case prev == gbRegionalIndicator && cur == gbRegionalIndicator && riCount%2 == 1: // GB12, GB13
+		return false
+	}
+	return true // GB999
+}
+
+func graphemePropOf(r rune) graphemeProp {
+	switch {
+	case r == '\r':
+		return gbCR
+	case r == '\n':
+		return gbLF
+	case r < 0x20 || r == 0x7f:
+		return gbControl
+	case r < 0x7f:
+		return gbOther // printable ASCII
+	case r == 0x200D:
+		return gbZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gbRegionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F: // emoji modifiers and tags
+		return gbExtend
+	case r >= 0xAC00 && r <= 0xD7A3: // precomposed hangul syllables
+		if (r-0xAC00)%28 == 0 {
+			return gbLV
+		}
+		return gbLVT
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return gbL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return gbV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return gbT
+	case unicode.Is(graphemePrepend, r):
+		return gbPrepend
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Other_Grapheme_Extend):
+		return gbExtend
+	case r == 0x0E33 || r == 0x0EB3 || unicode.Is(unicode.Mc, r):
+		return gbSpacingMark
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gbControl
+	case unicode.Is(extendedPictographic, r):
+		return gbExtPict
+	}
+	return gbOther
+}
+
+// graphemePrepend lists characters with Grapheme_Cluster_Break=Prepend
+var graphemePrepend = &unicode.RangeTable{ //nolint:gochecknoglobals // read-only unicode table
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x0605, Stride: 1},
+		{Lo: 0x06DD, Hi: 0x06DD, Stride: 1},
+		{Lo: 0x070F, Hi: 0x070F, Stride: 1},
+		{Lo: 0x0890, Hi: 0x0891, Stride: 1},
+		{Lo: 0x08E2, Hi: 0x08E2, Stride: 1},
+		{Lo: 0x0D4E, Hi: 0x0D4E, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x110BD, Hi: 0x110BD, Stride: 1},
+		{Lo: 0x110CD, Hi: 0x110CD, Stride: 1},
+		{Lo: 0x111C2, Hi: 0x111C3, Stride: 1},
+		{Lo: 0x1193F, Hi: 0x1193F, Stride: 1},
+		{Lo: 0x11941, Hi: 0x11941, Stride: 1},
+		{Lo: 0x11A3A, Hi: 0x11A3A, Stride: 1},
+		{Lo: 0x11A84, Hi: 0x11A89, Stride: 1},
+		{Lo: 0x11D46, Hi: 0x11D46, Stride: 1},
+		{Lo: 0x11F02, Hi: 0x11F02, Stride: 1},
+	},
+}
+
+// extendedPictographic lists characters with Extended_Pictographic=Yes, from emoji-data.txt
+var extendedPictographic = &unicode.RangeTable{ //nolint:gochecknoglobals // read-only unicode table
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+		{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21A9, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x2388, Hi: 0x2388, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x2605, Stride: 1},
+		{Lo: 0x2607, Hi: 0x2612, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2685, Stride: 1},
+		{Lo: 0x2690, Hi: 0x2705, Stride: 1},
+		{Lo: 0x2708, Hi: 0x2712, Stride: 1},
+		{Lo: 0x2714, Hi: 0x2714, Stride: 1},
+		{Lo: 0x2716, Hi: 0x2716, Stride: 1},
+		{Lo: 0x271D, Hi: 0x271D, Stride: 1},
+		{Lo: 0x2721, Hi: 0x2721, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x2733, Hi: 0x2734, Stride: 1},
+		{Lo: 0x2744, Hi: 0x2744, Stride: 1},
+		{Lo: 0x2747, Hi: 0x2747, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2763, Hi: 0x2767, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27A1, Hi: 0x27A1, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B07, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	LatinOffset: 2,
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F0FF, Stride: 1},
+		{Lo: 0x1F10D, Hi: 0x1F10F, Stride: 1},
+		{Lo: 0x1F12F, Hi: 0x1F12F, Stride: 1},
+		{Lo: 0x1F16C, Hi: 0x1F171, Stride: 1},
+		{Lo: 0x1F17E, Hi: 0x1F17F, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F1AD, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F201, Hi: 0x1F20F, Stride: 1},
+		{Lo: 0x1F21A, Hi: 0x1F21A, Stride: 1},
+		{Lo: 0x1F22F, Hi: 0x1F22F, Stride: 1},
+		{Lo: 0x1F232, Hi: 0x1F23A, Stride: 1},
+		{Lo: 0x1F23C, Hi: 0x1F23F, Stride: 1},
+		{Lo: 0x1F249, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1F53D, Stride: 1},
+		{Lo: 0x1F546, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1},
+		{Lo: 0x1F774, Hi: 0x1F77F, Stride: 1},
+		{Lo: 0x1F7D5, Hi: 0x1F7FF, Stride: 1},
+		{Lo: 0x1F80C, Hi: 0x1F80F, Stride: 1},
+		{Lo: 0x1F848, Hi: 0x1F84F, Stride: 1},
+		{Lo: 0x1F85A, Hi: 0x1F85F, Stride: 1},
+		{Lo: 0x1F888, Hi: 0x1F88F, Stride: 1},
+		{Lo: 0x1F8AE, Hi: 0x1F8FF, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F93A, Stride: 1},
+		{Lo: 0x1F93C, Hi: 0x1F945, Stride: 1},
+		{Lo: 0x1F947, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+	},
+}
@@ -0,0 +1,72 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphemes(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"crlf is one cluster", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"lf cr are two clusters", "\n\r", []string{"\n", "\r"}},
+		{"combining accent", "éx", []string{"é", "x"}},
+		{"multiple combining marks", "à́̂", []string{"à́̂"}},
+		{"flags", "🇺🇸🇬🇧", []string{"🇺🇸", "🇬🇧"}},
+		{"odd regional indicators", "🇺🇸🇬", []string{"🇺🇸", "🇬"}},
+		{"skin tone modifier", "👍🏽!", []string{"👍🏽", "!"}},
+		{"zwj family", "👨‍👩‍👧x", []string{"👨‍👩‍👧", "x"}},
+		{"zwj with skin tones", "👩🏽‍💻", []string{"👩🏽‍💻"}},
+		{"variation selector", "❤️a", []string{"❤️", "a"}},
+		{"zwj not after pictographic", "a‍b", []string{"a‍", "b"}},
+		{"hangul jamo", "각ᄀ", []string{"각", "ᄀ"}},
+		{"hangul syllables", "한국", []string{"한", "국"}},
+		{"hangul lv with trailing jamo", "각", []string{"각"}},
+		{"devanagari spacing mark", "कि", []string{"कि"}},
+		{"prepend", "؀a", []string{"؀a"}},
+		{"control breaks", "a\u0001́", []string{"a", "\u0001", "́"}},
+		{"keycap", "1️⃣", []string{"1️⃣"}},
+		{"tag sequence", "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", []string{
+			"🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Graphemes(tt.s))
+			assert.Equal(t, len(tt.want), GraphemeCount(tt.s))
+		})
+	}
+}
+
+func TestTruncateGraphemes(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string
+	}{
+		{"no truncation needed", "hello", 10, "hello"},
+		{"truncation needed", "hello world", 6, "hel..."},
+		{"maxLen too small", "hello", 3, ""},
+		{"exactly at limit", "hello", 5, "hello"},
+		{"combining accents kept", "ééééé", 4, "é..."},
+		{"maxLen too small with accents", "ééé", 3, ""},
+		{"combining accents fit as graphemes", "éééé", 4, "éééé"},
+		{"flags not split", "🇺🇸🇬🇧🇩🇪🇫🇷🇮🇹", 4, "🇺🇸..."},
+		{"skin tone not split", "👍🏽👍🏽👍🏽👍🏽👍🏽", 4, "👍🏽..."},
+		{"zwj sequence not split", "👨‍👩‍👧 family photo", 5, "👨‍👩‍👧 ..."},
+		{"unicode string with truncation", "привет мир", 7, "прив..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TruncateGraphemes(tt.input, tt.maxLen))
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TruncateHTML cuts visible text of HTML string to the given length (in runes) and adds ellipsis if it was truncated.
+// Only text counts toward the limit, tags and comments don't, and a character entity like "&amp;" counts as one rune.
+// The ellipsis is put inside the innermost open element and all elements left open are closed, so
+// "<p><b>hello</b> world</p>" truncated to 6 becomes "<p><b>hel...</b></p>".
+// Same as Truncate, if maxLen is less than 4 (3 chars for ellipsis + 1 rune from string), returns empty string.
+func TruncateHTML(s string, maxLen int) string {
+	if maxLen < 4 {
+		return ""
+	}
+	tokens := htmlTokenize(s)
+	cut, visible := -1, 0
+	for i, t := range tokens {
+		if t.kind != htmlText {
+			continue
+		}
+		visible++
+		if visible == maxLen-3 {
+			cut = i + 1
+		}
+		if visible > maxLen {
+			return htmlCut(tokens[:cut])
+		}
+	}
+	return s
+}
+
+// TruncateHTMLWords cuts visible text of HTML string to the given number of words and adds ellipsis if it was truncated.
+// Words are separated by whitespace and by block-level tags like <p> or <br>. Markup and whitespace
+// between kept words stay as is. The ellipsis is put right after the last kept word, inside the innermost
+// open element, and all elements left open are closed.
+// Same as TruncateWords, returns empty string if maxWords is less than 1.
+func TruncateHTMLWords(s string, maxWords int) string {
+	if maxWords < 1 {
+		return ""
+	}
+	tokens := htmlTokenize(s)
+	cut, words, inWord := 0, 0, false
+	for i, t := range tokens {
+		switch {
+		case t.kind == htmlTag && htmlBlockElement(t.name):
+			inWord = false
+		case t.kind != htmlText: // inline tags and comments don't split words
+		case t.space:
+			inWord = false
+		case !inWord:
+			inWord = true
+			words++
+			if words > maxWords {
+				return htmlCut(tokens[:cut])
+			}
+			cut = i + 1
+		default:
+			cut = i + 1
+		}
+	}
+	return s
+}
+
+type htmlTokenKind int
+
+const (
+	htmlText  htmlTokenKind = iota // single visible rune or character entity
+	htmlTag                        // opening, closing or self-closing tag
+	htmlOther                      // comment, doctype, processing instruction or raw text of script and style
+)
+
+type htmlToken struct {
+	kind    htmlTokenKind
+	raw     string
+	name    string // lowercase tag name
+	closing bool   // closing tag, </b>
+	selfEnd bool   // self-closing or void tag, <br/>, <img>
+	space   bool   // whitespace text
+}
+
+// htmlTokenize splits HTML into tags, visible text units and other markup. It is lenient to
+// malformed input, a "<" or "&" which doesn't start a tag or an entity is treated as text.
+func htmlTokenize(s string) []htmlToken {
+	var tokens []htmlToken
+	for pos := 0; pos < len(s); {
+		rest := s[pos:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			n := len(rest)
+			if end >= 0 {
+				n = end + 7
+			}
+			tokens = append(tokens, htmlToken{kind: htmlOther, raw: rest[:n]})
+			pos += n
+			continue
+		case rest[0] == '<' && len(rest) > 1 && (rest[1] == '!' || rest[1] == '?'):
+			n := strings.IndexByte(rest, '>') + 1
+			if n == 0 {
+				n = len(rest)
+			}
+			tokens = append(tokens, htmlToken{kind: htmlOther, raw: rest[:n]})
+			pos += n
+			continue
+		case rest[0] == '<':
+			if t, ok := htmlParseTag(rest); ok {
+				tokens = append(tokens, t)
+				pos += len(t.raw)
+				if (t.name == "script" || t.name == "style") && !t.closing && !t.selfEnd {
+					n := htmlRawTextLen(s[pos:], t.name)
+					if n > 0 {
+						tokens = append(tokens, htmlToken{kind: htmlOther, raw: s[pos : pos+n]})
+						pos += n
+					}
+				}
+				continue
+			}
+		case rest[0] == '&':
+			if n := htmlEntityLen(rest); n > 0 {
+				tokens = append(tokens, htmlToken{kind: htmlText, raw: rest[:n]})
+				pos += n
+				continue
+			}
+		}
+		r, n := utf8.DecodeRuneInString(rest)
+		tokens = append(tokens, htmlToken{kind: htmlText, raw: rest[:n], space: unicode.IsSpace(r)})
+		pos += n
+	}
+	return tokens
+}
+
+// htmlParseTag parses a tag at the beginning of s, quoted attribute values may contain ">"
+func htmlParseTag(s string) (htmlToken, bool) {
+	t := htmlToken{kind: htmlTag}
+	i := 1
+	if i < len(s) && s[i] == '/' {
+		t.closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && (isASCIILetter(s[i]) || (i > start && (s[i] == '-' || (s[i] >= '0' && s[i] <= '9')))) {
+		i++
+	}
+	if i == start {
+		return t, false
+	}
+	t.name = strings.ToLower(s[start:i])
+	var quote byte
+	for ; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '>':
+			t.raw = s[:i+1]
+			t.selfEnd = !t.closing && (s[i-1] == '/' || htmlVoidElement(t.name))
+			return t, true
+		}
+	}
+	return t, false
+}
+
+// htmlRawTextLen returns length of raw text of script or style element up to its closing tag.
+// The tag is matched ignoring ASCII case only, so offsets in s are never shifted by case mapping.
+func htmlRawTextLen(s, name string) int {
+	tag := "</" + name
+	for i := 0; i+len(tag) <= len(s); i++ {
+		if s[i] == '<' && hasPrefixASCIIFold(s[i:], tag) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// hasPrefixASCIIFold checks if s starts with lowercase ASCII prefix, ignoring case of ASCII letters in s
+func hasPrefixASCIIFold(s, prefix string) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// htmlEntityLen returns length of a character reference like "&amp;" or "&#x27;" at the beginning of s, or 0
+func htmlEntityLen(s string) int {
+	const maxEntityLen = 32
+	for i := 1; i < len(s) && i < maxEntityLen; i++ {
+		c := s[i]
+		switch {
+		case c == ';':
+			if i == 1 {
+				return 0
+			}
+			return i + 1
+		case isASCIILetter(c), c >= '0' && c <= '9', c == '#' && i == 1:
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// htmlCut writes tokens, then the ellipsis and closing tags for all elements left open
+func htmlCut(tokens []htmlToken) string {
+	var b strings.Builder
+	var open []string
+	for _, t := range tokens {
+		b.WriteString(t.raw)
+		if t.kind != htmlTag || t.selfEnd {
+			continue
+		}
+		if !t.closing {
+			open = append(open, t.name)
+			continue
+		}
+		for i := len(open) - 1; i >= 0; i-- {
+			if open[i] == t.name {
+				open = open[:i]
+				break
+			}
+		}
+	}
+	b.WriteString("...")
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String()
+}
+
+func htmlVoidElement(name string) bool {
+	switch name {
+	case "area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "param", "source", "track", "wbr":
+		return true
+	}
+	return false
+}
+
+func htmlBlockElement(name string) bool {
+	switch name {
+	case "address", "article", "aside", "blockquote", "br", "dd", "div", "dl", "dt", "figcaption", "figure", "footer",
+		"h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav", "ol", "p", "pre", "section",
+		"table", "td", "th", "tr", "ul":
+		return true
+	}
+	return false
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
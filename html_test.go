@@ -0,0 +1,80 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string
+	}{
+		{"no truncation needed", "<p>hello</p>", 10, "<p>hello</p>"},
+		{"tags don't count", "<p><b>hello</b></p>", 5, "<p><b>hello</b></p>"},
+		{"maxLen too small", "<p>hello</p>", 3, ""},
+		{"plain text same as Truncate", "hello world", 6, "hel..."},
+		{"closes open tags", "<p><b>hello</b> world</p>", 6, "<p><b>hel...</b></p>"},
+		{"ellipsis in innermost element", "<p>hello <i>beautiful</i> world</p>", 12, "<p>hello <i>bea...</i></p>"},
+		{"cut at the end of element", "<p><b>hello</b> world</p>", 8, "<p><b>hello...</b></p>"},
+		{"entity counts as one", "<p>a &amp; b &lt; c and more</p>", 8, "<p>a &amp; b...</p>"},
+		{"numeric entity", "<p>&#x27;quoted&#39; text here</p>", 8, "<p>&#x27;quot...</p>"},
+		{"entity exactly fits", "&amp;&amp;&amp;&amp;", 4, "&amp;&amp;&amp;&amp;"},
+		{"bare ampersand is text", "a & b & c & d", 6, "a &..."},
+		{"void elements not closed", "<p>one<br>two<img src=\"x.png\">three</p>", 10, "<p>one<br>two<img src=\"x.png\">t...</p>"},
+		{"self-closing not closed", "<div>ab<span/>cdefg</div>", 6, "<div>ab<span/>c...</div>"},
+		{"attributes with gt", "<a title=\"a>b\" href='x'>link text here</a>", 7, "<a title=\"a>b\" href='x'>link...</a>"},
+		{"comments are invisible", "<!-- long comment -->hello world", 7, "<!-- long comment -->hell..."},
+		{"script is invisible", "<script>var x = 1 < 2;</script><p>hello world</p>", 6,
+			"<script>var x = 1 < 2;</script><p>hel...</p>"},
+		{"non-ascii script growing in lowercase", "<script>ȺȺȺȺȺȺȺȺȺȺ</script>hello world", 6,
+			"<script>ȺȺȺȺȺȺȺȺȺȺ</script>hel..."},
+		{"non-ascii script with dotted capital i", "<script>İİİİİİİİİİ</script>hello world", 6,
+			"<script>İİİİİİİİİİ</script>hel..."},
+		{"non-ascii style", "<STYLE>p::after{content:\"ȺİȺİ\"}</Style>hello world", 6,
+			"<STYLE>p::after{content:\"ȺİȺİ\"}</Style>hel..."},
+		{"unclosed script", "<script>ȺȺȺȺȺȺȺȺȺȺ", 6, "<script>ȺȺȺȺȺȺȺȺȺȺ"},
+		{"unicode text", "<b>привет мир</b>", 7, "<b>прив...</b>"},
+		{"uppercase tags", "<P><B>hello</B> world</P>", 6, "<P><B>hel...</b></p>"},
+		{"unmatched closing tag kept", "hello</b> world", 9, "hello</b> ..."},
+		{"lone less than is text", "1 < 2 and 3 > 2", 7, "1 < ..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TruncateHTML(tt.input, tt.maxLen))
+		})
+	}
+}
+
+func TestTruncateHTMLWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxWords int
+		want     string
+	}{
+		{"no truncation needed", "<p>hello world</p>", 2, "<p>hello world</p>"},
+		{"zero max words", "<p>hello</p>", 0, ""},
+		{"plain text", "hello beautiful world", 2, "hello beautiful..."},
+		{"closes open tags", "<p>hello <b>beautiful world</b></p>", 2, "<p>hello <b>beautiful...</b></p>"},
+		{"ellipsis after last word", "<p><b>hello</b> <i>world</i></p>", 1, "<p><b>hello...</b></p>"},
+		{"whitespace kept", "<p>hello\n  beautiful world</p>", 2, "<p>hello\n  beautiful...</p>"},
+		{"inline tags don't split words", "<p>hel<b>lo</b> world</p>", 1, "<p>hel<b>lo...</b></p>"},
+		{"block tags split words", "<p>one</p><p>two</p><p>three</p>", 2, "<p>one</p><p>two...</p>"},
+		{"non-ascii script", "<script>ȺȺȺȺȺȺȺȺȺȺ</script><p>one two three</p>", 2,
+			"<script>ȺȺȺȺȺȺȺȺȺȺ</script><p>one two...</p>"},
+		{"non-ascii style", "<style>İİİİ İİİİ</style>one two three", 1, "<style>İİİİ İİİİ</style>one..."},
+		{"br splits words", "one<br>two<br>three", 1, "one..."},
+		{"entities are part of words", "<p>R&amp;D team rocks</p>", 2, "<p>R&amp;D team...</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TruncateHTMLWords(tt.input, tt.maxWords))
+		})
+	}
+}
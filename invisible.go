@@ -0,0 +1,112 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// InvisibleCategory is a set of categories of characters rendered as nothing or as blank space,
+// which are not whitespace for unicode.IsSpace. Categories can be combined with "|".
+type InvisibleCategory uint
+
+const (
+	// InvisibleZeroWidth is zero-width space U+200B, zero-width non-joiner and joiner U+200C and U+200D,
+	// word joiner U+2060, byte order mark U+FEFF and Mongolian vowel separator U+180E.
+	// Zero-width joiner is also used inside emoji sequences like 👨‍👩‍👧, which fall apart without it.
+	InvisibleZeroWidth InvisibleCategory = 1 << iota
+	// InvisibleBidi is bidirectional text controls: left-to-right and right-to-left marks, Arabic letter mark,
+	// embeddings, overrides and isolates
+	InvisibleBidi
+	// InvisibleFiller is characters displayed as blank space while being letters or symbols:
+	// Hangul fillers U+115F, U+1160, U+3164, U+FFA0 and braille pattern blank U+2800
+	InvisibleFiller
+	// InvisibleFormat is other invisible format characters: soft hyphen, combining grapheme joiner,
+	// invisible math operators, deprecated format characters, interlinear annotation and tag characters
+	InvisibleFormat
+	// InvisibleVariationSelector is variation selectors choosing glyph variants, like text or emoji presentation.
+	// Without them the base character is shown in its default form.
+	InvisibleVariationSelector
+
+	// InvisibleAll is all invisible categories
+	InvisibleAll = InvisibleZeroWidth | InvisibleBidi | InvisibleFiller | InvisibleFormat | InvisibleVariationSelector
+)
+
+// invisibleTables maps each invisible category to its characters
+var invisibleTables = []struct { //nolint:gochecknoglobals // read-only unicode tables
+	category InvisibleCategory
+	table    *unicode.RangeTable
+}{
+	{InvisibleZeroWidth, &unicode.RangeTable{R16: []unicode.Range16{
+		{Lo: 0x180E, Hi: 0x180E, Stride: 1},
+		{Lo: 0x200B, Hi: 0x200D, Stride: 1},
+		{Lo: 0x2060, Hi: 0x2060, Stride: 1},
+		{Lo: 0xFEFF, Hi: 0xFEFF, Stride: 1},
+	}}},
+	{InvisibleBidi, &unicode.RangeTable{R16: []unicode.Range16{
+		{Lo: 0x061C, Hi: 0x061C, Stride: 1},
+		{Lo: 0x200E, Hi: 0x200F, Stride: 1},
+		{Lo: 0x202A, Hi: 0x202E, Stride: 1},
+		{Lo: 0x2066, Hi: 0x2069, Stride: 1},
+	}}},
+	{InvisibleFiller, &unicode.RangeTable{R16: []unicode.Range16{
+		{Lo: 0x115F, Hi: 0x1160, Stride: 1},
+		{Lo: 0x2800, Hi: 0x2800, Stride: 1},
+		{Lo: 0x3164, Hi: 0x3164, Stride: 1},
+		{Lo: 0xFFA0, Hi: 0xFFA0, Stride: 1},
+	}}},
+	{InvisibleFormat, &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x00AD, Hi: 0x00AD, Stride: 1},
+			{Lo: 0x034F, Hi: 0x034F, Stride: 1},
+			{Lo: 0x17B4, Hi: 0x17B5, Stride: 1},
+			{Lo: 0x2061, Hi: 0x2064, Stride: 1},
+			{Lo: 0x206A, Hi: 0x206F, Stride: 1},
+			{Lo: 0xFFF9, Hi: 0xFFFB, Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x1BCA0, Hi: 0x1BCA3, Stride: 1},
+			{Lo: 0x1D173, Hi: 0x1D17A, Stride: 1},
+			{Lo: 0xE0001, Hi: 0xE0001, Stride: 1},
+			{Lo: 0xE0020, Hi: 0xE007F, Stride: 1},
+		},
+	}},
+	{InvisibleVariationSelector, &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x180B, Hi: 0x180D, Stride: 1},
+			{Lo: 0x180F, Hi: 0x180F, Stride: 1},
+			{Lo: 0xFE00, Hi: 0xFE0F, Stride: 1},
+		},
+		R32: []unicode.Range32{
+			{Lo: 0xE0100, Hi: 0xE01EF, Stride: 1},
+		},
+	}},
+}
+
+// InvisibleCategoryOf returns the invisible category of r, or 0 if r is not invisible
+func InvisibleCategoryOf(r rune) InvisibleCategory {
+	if r < 0xAD {
+		return 0 // fast path for ASCII
+	}
+	for _, t := range invisibleTables {
+		if unicode.Is(t.table, r) {
+			return t.category
+		}
+	}
+	return 0
+}
+
+// IsInvisible checks if r belongs to any invisible category
+func IsInvisible(r rune) bool {
+	return InvisibleCategoryOf(r) != 0
+}
+
+// StripInvisible removes characters of all invisible categories from s.
+// Note that it also removes zero-width joiners and variation selectors used in emoji sequences.
+func StripInvisible(s string) string {
+	return strings.Map(func(r rune) rune {
+		if IsInvisible(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,80 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvisibleCategoryOf(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want InvisibleCategory
+	}{
+		{"ascii letter", 'a', 0},
+		{"space", ' ', 0},
+		{"no-break space is whitespace", '\u00a0', 0},
+		{"zero-width space", '\u200b', InvisibleZeroWidth},
+		{"zero-width joiner", '\u200d', InvisibleZeroWidth},
+		{"word joiner", '\u2060', InvisibleZeroWidth},
+		{"bom", '\ufeff', InvisibleZeroWidth},
+		{"mongolian vowel separator", '\u180e', InvisibleZeroWidth},
+		{"left-to-right mark", '\u200e', InvisibleBidi},
+		{"right-to-left override", '\u202e', InvisibleBidi},
+		{"first strong isolate", '\u2068', InvisibleBidi},
+		{"arabic letter mark", '\u061c', InvisibleBidi},
+		{"hangul filler", '\u3164', InvisibleFiller},
+		{"hangul choseong filler", '\u115f', InvisibleFiller},
+		{"halfwidth hangul filler", '\uffa0', InvisibleFiller},
+		{"braille blank", '\u2800', InvisibleFiller},
+		{"braille dot", '⠁', 0},
+		{"soft hyphen", '\u00ad', InvisibleFormat},
+		{"invisible times", '\u2062', InvisibleFormat},
+		{"tag latin a", '\U000e0061', InvisibleFormat},
+		{"variation selector 16", '\ufe0f', InvisibleVariationSelector},
+		{"variation selector 17", '\U000e0100', InvisibleVariationSelector},
+		{"hangul letter", 'ㅎ', 0},
+		{"emoji", '👍', 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, InvisibleCategoryOf(tt.r))
+			assert.Equal(t, tt.want != 0, IsInvisible(tt.r))
+		})
+	}
+}
+
+func TestInvisibleCategory_Flags(t *testing.T) {
+	categories := []InvisibleCategory{InvisibleZeroWidth, InvisibleBidi, InvisibleFiller, InvisibleFormat,
+		InvisibleVariationSelector}
+	var all InvisibleCategory
+	for _, c := range categories {
+		assert.Zero(t, all&c, "categories don't overlap")
+		all |= c
+	}
+	assert.Equal(t, InvisibleAll, all)
+}
+
+func TestStripInvisible(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"empty", "", ""},
+		{"plain", "hello world", "hello world"},
+		{"zero-width inside word", "he\u200bl\u200clo", "hello"},
+		{"only invisible", "\u200b\u2060\ufeff\u3164", ""},
+		{"bidi override", "abc\u202edef\u202c", "abcdef"},
+		{"soft hyphen", "hy\u00adphen", "hyphen"},
+		{"keeps whitespace", " a\u200b b ", " a b "},
+		{"emoji sequence falls apart", "👨\u200d👩\u200d👧", "👨👩👧"},
+		{"keeps non-latin", "привет\u200b 世界", "привет 世界"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StripInvisible(tt.s))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package stringutils
+
+import (
+	"slices"
+	"sort"
+)
+
+// Matcher is a compiled multi-pattern substring matcher based on the Aho-Corasick automaton.
+// It is built once from a list of patterns and scans the input in a single pass, independent
+// of the number of patterns. Empty patterns are skipped, same as in ContainsAnySubstring.
+// Matcher is immutable after construction and safe for concurrent use.
+type Matcher struct {
+	patterns []string
+	lens     []int    // lengths of patterns as compiled, folded for NewMatcherFold
+	fold     FoldMode // case folding of scanned strings
+	nodes    []acNode
+	first    [256]bool // bytes starting any pattern, used to skip input in the root state
+}
+
+// SubstringMatch describes a single occurrence of a pattern found by Matcher
+type SubstringMatch struct {
+	Pattern string // matched pattern
+	Index   int    // index of the pattern in the slice passed to NewMatcher
+	Start   int    // byte offset of the match start in the scanned string
+	End     int    // byte offset right after the match end
+}
+
+// acNode is a state of the automaton. Edges are kept sorted by byte for binary search.
+type acNode struct {
+	edges []acEdge
+	fail  int   // longest proper suffix state
+	out   []int // indexes of patterns ending in this state
+	link  int   // nearest state reachable by fail links with non-empty out, -1 if none
+}
+
+type acEdge struct {
+	b    byte
+	next int
+}
+
+// NewMatcher compiles patterns into a Matcher. Empty patterns are ignored
+// and duplicated patterns are reported for each of their indexes.
+func NewMatcher(patterns []string) *Matcher {
+	return newMatcher(patterns, FoldNone)
+}
+
+// NewMatcherFold compiles patterns into a Matcher finding them after case folding of both patterns and
+// scanned strings according to mode, for repeated ContainsAnySubstringFold checks. Matches reported by
+// FindAll keep the original patterns, while their offsets are in the folded string.
+func NewMatcherFold(patterns []string, mode FoldMode) *Matcher {
+	return newMatcher(patterns, mode)
+}
+
+func newMatcher(patterns []string, mode FoldMode) *Matcher {
+	m := &Matcher{patterns: slices.Clone(patterns), lens: make([]int, len(patterns)), fold: mode}
+	m.nodes = []acNode{{link: -1}}
+	for i, p := range patterns {
+		p = FoldString(p, mode)
+		m.lens[i] = len(p)
+		if p == "" {
+			continue // skip empty substrings
+		}
+		state := 0
+		for j := 0; j < len(p); j++ {
+			next := m.child(state, p[j])
+			if next < 0 {
+				next = m.addChild(state, p[j])
+			}
+			state = next
+		}
+		m.nodes[state].out = append(m.nodes[state].out, i)
+	}
+	m.buildLinks()
+	return m
+}
+
+// Match reports whether s contains any of the patterns
+func (m *Matcher) Match(s string) bool {
+	if m == nil || len(m.nodes) == 1 {
+		return false
+	}
+	s = FoldString(s, m.fold)
+	state := 0
+	for i := 0; i < len(s); i++ {
+		if state == 0 && !m.first[s[i]] {
+			continue
+		}
+		state = m.step(state, s[i])
+		if len(m.nodes[state].out) > 0 || m.nodes[state].link >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAll returns all occurrences of all patterns in s, including overlapping ones.
+// Matches are ordered by end offset, and by pattern index for matches ending at the same offset.
+func (m *Matcher) FindAll(s string) []SubstringMatch {
+	if m == nil || len(m.nodes) == 1 {
+		return nil
+	}
+	s = FoldString(s, m.fold)
+	var result []SubstringMatch
+	state := 0
+	for i := 0; i < len(s); i++ {
+		if state == 0 && !m.first[s[i]] {
+			continue
+		}
+		state = m.step(state, s[i])
+		start := len(result)
+		for st := state; st >= 0; st = m.nodes[st].link {
+			for _, idx := range m.nodes[st].out {
+				result = append(result, SubstringMatch{Pattern: m.patterns[idx], Index: idx, Start: i + 1 - m.lens[idx], End: i + 1})
+			}
+		}
+		if found := result[start:]; len(found) > 1 {
+			sort.Slice(found, func(a, b int) bool { return found[a].Index < found[b].Index })
+		}
+	}
+	return result
+}
+
+// MatchedPatterns returns patterns found in s, each reported once and ordered by their index
+func (m *Matcher) MatchedPatterns(s string) []string {
+	matches := m.FindAll(s)
+	if len(matches) == 0 {
+		return nil
+	}
+	seen := make(map[int]struct{}, len(matches))
+	indexes := make([]int, 0, len(matches))
+	for _, mt := range matches {
+		if _, ok := seen[mt.Index]; !ok {
+			seen[mt.Index] = struct{}{}
+			indexes = append(indexes, mt.Index)
+		}
+	}
+	sort.Ints(indexes)
+	result := make([]string, len(indexes))
+	for i, idx := range indexes {
+		result[i] = m.patterns[idx]
+	}
+	return result
+}
+
+// child returns the state reached from state by byte b, or -1 if there is no such edge
+func (m *Matcher) child(state int, b byte) int {
+	edges := m.nodes[state].edges
+	i := sort.Search(len(edges), func(i int) bool { return edges[i].b >= b })
+	if i < len(edges) && edges[i].b == b {
+		return edges[i].next
+	}
+	return -1
+}
+
+func (m *Matcher) addChild(state int, b byte) int {
+	next := len(m.nodes)
+	m.nodes = append(m.nodes, acNode{link: -1})
+	edges := m.nodes[state].edges
+	i := sort.Search(len(edges), func(i int) bool { return edges[i].b >= b })
+	edges = append(edges, acEdge{})
+	copy(edges[i+1:], edges[i:])
+	edges[i] = acEdge{b: b, next: next}
+	m.nodes[state].edges = edges
+	return next
+}
+
+// step follows the goto function, falling back by fail links when there is no edge
+func (m *Matcher) step(state int, b byte) int {
+	for {
+		if next := m.child(state, b); next >= 0 {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = m.nodes[state].fail
+	}
+}
+
+// buildLinks computes fail and output links in breadth-first order
+func (m *Matcher) buildLinks() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, e := range m.nodes[0].edges {
+		m.first[e.b] = true
+		queue = append(queue, e.next) // depth 1 states fail to the root
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, e := range m.nodes[state].edges {
+			fail := m.nodes[state].fail
+			for fail != 0 && m.child(fail, e.b) < 0 {
+				fail = m.nodes[fail].fail
+			}
+			if f := m.child(fail, e.b); f >= 0 && f != e.next {
+				fail = f
+			}
+			m.nodes[e.next].fail = fail
+			if len(m.nodes[fail].out) > 0 {
+				m.nodes[e.next].link = fail
+			} else {
+				m.nodes[e.next].link = m.nodes[fail].link
+			}
+			queue = append(queue, e.next)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package stringutils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		s        string
+		want     bool
+	}{
+		{"single pattern found", []string{"world"}, "hello world", true},
+		{"single pattern not found", []string{"missing"}, "hello world", false},
+		{"nil patterns", nil, "hello world", false},
+		{"only empty patterns", []string{"", ""}, "hello world", false},
+		{"empty input", []string{"a"}, "", false},
+		{"suffix of another pattern", []string{"abcd", "bc"}, "xbcx", true},
+		{"fail link needed", []string{"abcx", "bcd"}, "abcd", true},
+		{"pattern is whole string", []string{"hello"}, "hello", true},
+		{"pattern longer than string", []string{"hello world!"}, "hello", false},
+		{"unicode", []string{"мир", "test"}, "привет мир", true},
+		{"case sensitive", []string{"WORLD"}, "hello world", false},
+		{"shared prefixes", []string{"he", "her", "hers"}, "ahishers", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewMatcher(tt.patterns).Match(tt.s))
+		})
+	}
+}
+
+func TestMatcher_FindAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		s        string
+		want     []SubstringMatch
+	}{
+		{"no matches", []string{"x"}, "abc", nil},
+		{"classic example", []string{"he", "she", "his", "hers"}, "ushers", []SubstringMatch{
+			{Pattern: "he", Index: 0, Start: 2, End: 4},
+			{Pattern: "she", Index: 1, Start: 1, End: 4},
+			{Pattern: "hers", Index: 3, Start: 2, End: 6},
+		}},
+		{"overlapping occurrences", []string{"aa"}, "aaaa", []SubstringMatch{
+			{Pattern: "aa", Index: 0, Start: 0, End: 2},
+			{Pattern: "aa", Index: 0, Start: 1, End: 3},
+			{Pattern: "aa", Index: 0, Start: 2, End: 4},
+		}},
+		{"duplicated patterns", []string{"ab", "", "ab"}, "xab", []SubstringMatch{
+			{Pattern: "ab", Index: 0, Start: 1, End: 3},
+			{Pattern: "ab", Index: 2, Start: 1, End: 3},
+		}},
+		{"unicode byte offsets", []string{"мир"}, "привет мир", []SubstringMatch{
+			{Pattern: "мир", Index: 0, Start: 13, End: 19},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMatcher(tt.patterns).FindAll(tt.s)
+			assert.Equal(t, tt.want, got)
+			for _, m := range got {
+				assert.Equal(t, m.Pattern, tt.s[m.Start:m.End])
+			}
+		})
+	}
+}
+
+func TestMatcher_MatchedPatterns(t *testing.T) {
+	m := NewMatcher([]string{"hers", "he", "xyz", "she"})
+	assert.Equal(t, []string{"hers", "he", "she"}, m.MatchedPatterns("ushers and he"))
+	assert.Nil(t, m.MatchedPatterns("nothing"))
+
+	var nilMatcher *Matcher
+	assert.False(t, nilMatcher.Match("abc"))
+	assert.Nil(t, nilMatcher.FindAll("abc"))
+}
+
+func TestMatcher_PatternsCopied(t *testing.T) {
+	patterns := []string{"foo", "bar"}
+	m := NewMatcher(patterns)
+	patterns[0] = "changed"
+	assert.Equal(t, []string{"foo"}, m.MatchedPatterns("foo"))
+	assert.Equal(t, "foo", m.FindAll("foo")[0].Pattern)
+}
+
+func TestMatcher_SameAsContains(t *testing.T) {
+	patterns := []string{"abc", "bcd", "cab", "dd", "a", "bb", "cdc"}
+	inputs := []string{"", "a", "xyz", "abcd", "ddd", "cbcbcb", "bcdcab", "zzzcdczzz", "bxb"}
+	m := NewMatcher(patterns)
+	for _, s := range inputs {
+		var want []string
+		for _, p := range patterns {
+			if strings.Contains(s, p) {
+				want = append(want, p)
+			}
+		}
+		assert.Equal(t, want, m.MatchedPatterns(s), "input %q", s)
+		assert.Equal(t, len(want) > 0, m.Match(s), "input %q", s)
+	}
+}
+
+func TestContainsAnySubstring_Matcher(t *testing.T) {
+	patterns := []string{""}
+	for i := 0; i < 500; i++ {
+		patterns = append(patterns, fmt.Sprintf("blocked-%03d", i))
+	}
+	input := strings.Repeat("some innocent text without bad words ", 100)
+	require.True(t, matcherPaysOff(input, patterns), "long input and many substrings are checked by matcher")
+	assert.False(t, ContainsAnySubstring(input, patterns))
+	assert.True(t, ContainsAnySubstring(input+"blocked-499", patterns))
+	assert.True(t, ContainsAnySubstring("blocked-007"+input, patterns))
+
+	require.False(t, matcherPaysOff("short blocked-001", patterns), "short input is checked substring by substring")
+	assert.True(t, ContainsAnySubstring("short blocked-001", patterns))
+	assert.False(t, ContainsAnySubstring("short", patterns))
+}
+
+func BenchmarkContainsAnySubstring(b *testing.B) {
+	for _, tc := range []struct{ patterns, repeat int }{{3, 1}, {50, 1}, {50, 30}, {500, 300}, {5000, 1}, {5000, 30}} {
+		patterns := make([]string, tc.patterns)
+		for i := range patterns {
+			patterns[i] = fmt.Sprintf("blocked-%05d", i)
+		}
+		input := strings.Repeat("some innocent text without bad words ", tc.repeat)
+		name := fmt.Sprintf("%d patterns %d bytes", tc.patterns, len(input))
+
+		b.Run(name+" each", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = containsEach(input, patterns)
+			}
+		})
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = ContainsAnySubstring(input, patterns)
+			}
+		})
+	}
+}
+
+func BenchmarkMatcher(b *testing.B) {
+	patterns := make([]string, 5000)
+	for i := range patterns {
+		patterns[i] = fmt.Sprintf("blocked-%05d", i)
+	}
+	input := strings.Repeat("some innocent text without bad words ", 30)
+
+	b.Run("matcher", func(b *testing.B) {
+		m := NewMatcher(patterns)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.Match(input)
+		}
+	})
+
+	b.Run("find all", func(b *testing.B) {
+		m := NewMatcher(patterns)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m.FindAll(input)
+		}
+	})
+
+	b.Run("contains any substring", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = ContainsAnySubstring(input, patterns)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalForm defines Unicode normalization form, see Unicode Standard Annex #15
+type NormalForm int
+
+const (
+	// FormNone keeps string as is
+	FormNone NormalForm = iota
+	// NFC is canonical composition, "e" with combining acute accent becomes precomposed "é"
+	NFC
+	// NFD is canonical decomposition, precomposed "é" becomes "e" with combining acute accent
+	NFD
+	// NFKC is compatibility composition, like NFC but also replaces compatibility characters,
+	// the "ﬁ" ligature becomes "fi" and full-width "Ａ" becomes "A"
+	NFKC
+	// NFKD is compatibility decomposition, like NFD but also replaces compatibility characters
+	NFKD
+)
+
+// NormalizeOptions defines steps of Normalize. Zero value changes nothing.
+type NormalizeOptions struct {
+	Form               NormalForm // Unicode normalization form, NFC if StripDiacritics is set and no form given
+	Fold               FoldMode   // case folding, none by default
+	StripDiacritics    bool       // remove combining marks after canonical decomposition, "café" becomes "cafe"
+	CollapseWhitespace bool       // replace whitespace runs with single space and trim, as NormalizeWhitespace does
+}
+
+// NormalizeForm returns s in the given Unicode normalization form
+func NormalizeForm(s string, form NormalForm) string {
+	switch form {
+	case NFC:
+		return norm.NFC.String(s)
+	case NFD:
+		return norm.NFD.String(s)
+	case NFKC:
+		return norm.NFKC.String(s)
+	case NFKD:
+		return norm.NFKD.String(s)
+	default:
+		return s
+	}
+}
+
+// Normalize applies steps of opts to s: Unicode normalization, case folding, stripping of diacritics and whitespace
+// collapsing. With a normalization form or StripDiacritics the string is decomposed first, canonically or with
+// compatibility decomposition for NFKC and NFKD, then folded and stripped, and composed to the form at the end,
+// as Unicode toNFKC_Casefold does, so the result doesn't depend on the order of folding and normalization.
+// With StripDiacritics letters without canonical decomposition, like "ø" or "ł", are kept as is.
+// To compare strings ignoring case, accents and different encodings of the same text use
+// NormalizeOptions{Form: NFKC, Fold: FoldFull, StripDiacritics: true, CollapseWhitespace: true}.
+func Normalize(s string, opts NormalizeOptions) string {
+	form := opts.Form
+	if opts.StripDiacritics && form == FormNone {
+		form = NFC
+	}
+	if form == FormNone {
+		s = FoldString(s, opts.Fold)
+	} else {
+		decomposition := NFD
+		if form == NFKC || form == NFKD {
+			decomposition = NFKD
+		}
+		s = FoldString(NormalizeForm(s, decomposition), opts.Fold)
+		if opts.StripDiacritics {
+			// folding may add combining marks, like "İ" folded to "i̇", decompose again to strip them all
+			s = strings.Map(func(r rune) rune {
+				if unicode.Is(unicode.Mn, r) {
+					return -1
+				}
+				return r
+			}, NormalizeForm(s, decomposition))
+		}
+		s = NormalizeForm(s, form)
+	}
+	if opts.CollapseWhitespace {
+		s = NormalizeWhitespace(s)
+	}
+	return s
+}
+
+// NewNormalizer returns function normalizing strings with opts, to be used as key function of
+// DeDupFunc, ContainsFunc and other *Func functions. In all of them nil key compares strings as is.
+func NewNormalizer(opts NormalizeOptions) func(string) string {
+	return func(s string) string { return Normalize(s, opts) }
+}
+
+// ContainsFunc checks if slice contains a string with the same key as src
+func ContainsFunc(src string, inSlice []string, key func(string) string) bool {
+	key = keyOrSelf(key)
+	k := key(src)
+	for _, s := range inSlice {
+		if key(s) == k {
+			return true
+		}
+	}
+	return false
+}
+
+// DeDupFunc removes strings with the same key from slice, keeping the first occurrence of each key.
+// Like DeDup, it preserves the order of first occurrences.
+func DeDupFunc(keys []string, key func(string) string) []string {
+	key = keyOrSelf(key)
+	if len(keys) == 0 {
+		return nil
+	}
+	seen := NewSet[string]()
+	result := make([]string, 0, len(keys))
+	for _, s := range keys {
+		k := key(s)
+		if !seen.Has(k) {
+			seen.Add(k)
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// HasCommonElementFunc checks if any element of the second slice has the same key as an element of the first slice
+func HasCommonElementFunc(a, b []string, key func(string) string) bool {
+	key = keyOrSelf(key)
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	set := keySet(a, key)
+	for _, s := range b {
+		if set.Has(key(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DifferenceFunc returns elements of the first slice without the same key in the second slice.
+// Like Difference, duplicates in the first slice are kept.
+func DifferenceFunc(a, b []string, key func(string) string) []string {
+	key = keyOrSelf(key)
+	if len(a) == 0 {
+		return nil
+	}
+	if len(b) == 0 {
+		return a
+	}
+	bSet := keySet(b, key)
+	result := make([]string, 0, len(a))
+	for _, s := range a {
+		if !bSet.Has(key(s)) {
+			result = append(result, s)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// UnionFunc combines multiple slices and removes strings with the same key, keeping the first occurrence of each key
+func UnionFunc(key func(string) string, slices ...[]string) []string {
+	var all []string
+	for _, slice := range slices {
+		all = append(all, slice...)
+	}
+	return DeDupFunc(all, key)
+}
+
+// IntersectionFunc returns elements of the first slice with the same key in the second slice,
+// keeping the first occurrence of each key and the order of the first slice
+func IntersectionFunc(a, b []string, key func(string) string) []string {
+	key = keyOrSelf(key)
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	bSet := keySet(b, key)
+	seen := NewSet[string]()
+	var result []string
+	for _, s := range a {
+		k := key(s)
+		if bSet.Has(k) && !seen.Has(k) {
+			seen.Add(k)
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// keyOrSelf returns key, or function returning the string itself if key is nil
+func keyOrSelf(key func(string) string) func(string) string {
+	if key == nil {
+		return func(s string) string { return s }
+	}
+	return key
+}
+
+func keySet(slice []string, key func(string) string) *Set[string] {
+	set := NewSet[string]()
+	for _, s := range slice {
+		set.Add(key(s))
+	}
+	return set
+}
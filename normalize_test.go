@@ -0,0 +1,157 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cafeComposed   = "caf\u00e9"  // precomposed é
+	cafeDecomposed = "cafe\u0301" // e with combining acute accent
+)
+
+func TestNormalizeForm(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		form NormalForm
+		want string
+	}{
+		{"none", cafeDecomposed, FormNone, cafeDecomposed},
+		{"nfc", cafeDecomposed, NFC, cafeComposed},
+		{"nfd", cafeComposed, NFD, cafeDecomposed},
+		{"nfc keeps ligature", "ﬁle", NFC, "ﬁle"},
+		{"nfkc ligature", "ﬁle", NFKC, "file"},
+		{"nfkc full width", "Ａｂｃ１", NFKC, "Abc1"},
+		{"nfkd", "ﬁancé", NFKD, "fiance\u0301"},
+		{"hangul nfd", "한", NFD, "\u1112\u1161\u11ab"},
+		{"empty", "", NFC, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeForm(tt.s, tt.form))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		opts NormalizeOptions
+		want string
+	}{
+		{"zero options", " Café ", NormalizeOptions{}, " Café "},
+		{"form only", cafeDecomposed, NormalizeOptions{Form: NFC}, cafeComposed},
+		{"collapse whitespace", "  a \t b\n", NormalizeOptions{CollapseWhitespace: true}, "a b"},
+		{"fold", "STRAßE", NormalizeOptions{Fold: FoldFull}, "strasse"},
+		{"strip diacritics", "Crème brûlée", NormalizeOptions{StripDiacritics: true}, "Creme brulee"},
+		{"strip decomposed", cafeDecomposed, NormalizeOptions{StripDiacritics: true}, "cafe"},
+		{"strip keeps letters without decomposition", "Łódź ø", NormalizeOptions{StripDiacritics: true}, "Łodz ø"},
+		{"strip recomposes hangul", "한국어", NormalizeOptions{StripDiacritics: true}, "한국어"},
+		{"strip with nfd", "é한", NormalizeOptions{StripDiacritics: true, Form: NFD}, "e\u1112\u1161\u11ab"},
+		{"fold then strip dotted i", "İstanbul", NormalizeOptions{Fold: FoldFull, StripDiacritics: true}, "istanbul"},
+		{"all steps", " ＣＡＦÉ  ﬁle ", NormalizeOptions{Fold: FoldFull, StripDiacritics: true, Form: NFKC,
+			CollapseWhitespace: true}, "cafe file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.s, tt.opts))
+		})
+	}
+}
+
+func TestNormalize_FoldAndCompatibility(t *testing.T) {
+	opts := NormalizeOptions{Form: NFKC, Fold: FoldFull}
+	tests := []struct {
+		s, want string
+	}{
+		{"Ⅻ", "xii"},
+		{"Ｓ", "s"},
+		{"ＳＴＲＡẞＥ", "strasse"},
+		{"ᴬᴮ", "ab"}, // modifier capitals are uppercase only after decomposition
+		{"ϒ", "υ"},   // upsilon with hook symbol
+		{"Ϲ", "σ"},   // lunate sigma
+		{"ͺ", " ι"},  // ypogegrammeni decomposes to combining iota, folded to iota
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got := Normalize(tt.s, opts)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, got, Normalize(got, opts), "normalizing again changes nothing")
+		})
+	}
+	assert.Equal(t, "xii", Normalize("Ⅻ", NormalizeOptions{Form: NFKD, Fold: FoldSimple}))
+	assert.Equal(t, "ⅻ", Normalize("Ⅻ", NormalizeOptions{Form: NFC, Fold: FoldFull}), "canonical form keeps numerals")
+}
+
+func TestNewNormalizer(t *testing.T) {
+	key := NewNormalizer(NormalizeOptions{Form: NFC, Fold: FoldSimple})
+	assert.Equal(t, key(cafeDecomposed), key(strings.ToUpper(cafeComposed)))
+	assert.Equal(t, "café", key("CAFÉ"))
+}
+
+func TestKeyFuncs(t *testing.T) {
+	nfc := NewNormalizer(NormalizeOptions{Form: NFC})
+	loose := NewNormalizer(NormalizeOptions{Fold: FoldFull, StripDiacritics: true, CollapseWhitespace: true})
+
+	t.Run("contains", func(t *testing.T) {
+		assert.False(t, Contains(cafeDecomposed, []string{"tea", cafeComposed}))
+		assert.True(t, ContainsFunc(cafeDecomposed, []string{"tea", cafeComposed}, nfc))
+		assert.False(t, ContainsFunc("coffee", []string{"tea", cafeComposed}, nfc))
+		assert.True(t, ContainsFunc(" CAFE ", []string{"tea", cafeComposed}, loose))
+		assert.False(t, ContainsFunc("cafe", nil, loose))
+	})
+
+	t.Run("dedup", func(t *testing.T) {
+		in := []string{cafeComposed, "tea", cafeDecomposed, "Café", "TEA"}
+		assert.Len(t, DeDup(in), 5)
+		assert.Equal(t, []string{cafeComposed, "tea", "Café", "TEA"}, DeDupFunc(in, nfc))
+		assert.Equal(t, []string{cafeComposed, "tea"}, DeDupFunc(in, loose))
+		assert.Nil(t, DeDupFunc(nil, loose))
+	})
+
+	t.Run("has common element", func(t *testing.T) {
+		assert.False(t, HasCommonElement([]string{cafeComposed}, []string{cafeDecomposed}))
+		assert.True(t, HasCommonElementFunc([]string{"tea", cafeComposed}, []string{cafeDecomposed}, nfc))
+		assert.False(t, HasCommonElementFunc([]string{"tea"}, []string{cafeDecomposed}, nfc))
+		assert.False(t, HasCommonElementFunc(nil, []string{cafeDecomposed}, nfc))
+	})
+
+	t.Run("difference", func(t *testing.T) {
+		a := []string{cafeComposed, "tea", "Tea", "milk"}
+		assert.Equal(t, []string{"milk"}, DifferenceFunc(a, []string{cafeDecomposed, "TEA"}, loose))
+		assert.Equal(t, []string{"tea", "Tea", "milk"}, DifferenceFunc(a, []string{cafeDecomposed}, nfc))
+		assert.Equal(t, a, DifferenceFunc(a, nil, nfc))
+		assert.Nil(t, DifferenceFunc(a, a, nfc))
+		assert.Nil(t, DifferenceFunc(nil, a, nfc))
+	})
+
+	t.Run("union", func(t *testing.T) {
+		got := UnionFunc(nfc, []string{cafeComposed, "tea"}, []string{cafeDecomposed, "milk"}, nil)
+		assert.Equal(t, []string{cafeComposed, "tea", "milk"}, got)
+		assert.Nil(t, UnionFunc(nfc))
+	})
+
+	t.Run("nil key compares as is", func(t *testing.T) {
+		assert.True(t, ContainsFunc("a", []string{"b", "a"}, nil))
+		assert.False(t, ContainsFunc(cafeDecomposed, []string{cafeComposed}, nil))
+		assert.Equal(t, []string{"a", "A"}, DeDupFunc([]string{"a", "A", "a"}, nil))
+		assert.True(t, HasCommonElementFunc([]string{"a"}, []string{"b", "a"}, nil))
+		assert.Equal(t, []string{"A"}, DifferenceFunc([]string{"a", "A"}, []string{"a"}, nil))
+		assert.Equal(t, []string{"a", "b"}, UnionFunc(nil, []string{"a"}, []string{"b", "a"}))
+		assert.Equal(t, []string{"a"}, IntersectionFunc([]string{"A", "a"}, []string{"a"}, nil))
+	})
+
+	t.Run("intersection", func(t *testing.T) {
+		a := []string{"Tea", cafeDecomposed, "tea", "milk"}
+		b := []string{cafeComposed, "TEA"}
+		assert.Empty(t, Intersection(a, b))
+		assert.Equal(t, []string{cafeDecomposed}, IntersectionFunc(a, b, nfc))
+		assert.Equal(t, []string{"Tea", cafeDecomposed}, IntersectionFunc(a, b, loose))
+		assert.Nil(t, IntersectionFunc(a, []string{"water"}, loose))
+		assert.Nil(t, IntersectionFunc(nil, b, loose))
+	})
+}
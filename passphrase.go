@@ -0,0 +1,139 @@
+package stringutils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// PassphraseOptions defines how Passphrase builds a phrase. Zero values of numeric fields mean defaults.
+type PassphraseOptions struct {
+	Words      int     // number of words, 4 by default
+	MinWordLen int     // minimal length of a word, 4 by default, values below 2 are raised to 2 as in RandomWord
+	MaxWordLen int     // maximal length of a word, 8 by default, raised to MinWordLen if less
+	Separator  string  // separator between words, "-" by default
+	Capitalize bool    // make the first letter of every word uppercase, doesn't add entropy
+	Digits     int     // number of random digits appended to a random word
+	Symbols    int     // number of random symbols appended to a random word, after the digits if both go to the same word
+	MinEntropy float64 // minimal entropy in bits, words are added until the phrase reaches it
+}
+
+// passphraseSymbols doesn't include "-", "_" and "." to keep them distinct from usual separators
+const passphraseSymbols = "!#$%&*+=?@"
+
+const (
+	passphraseDefaultWords = 4
+	passphraseMaxWords     = 256
+)
+
+// Passphrase generates a phrase of random pronounceable words using crypto/rand.
+// Returns the phrase and its entropy in bits, see Generator.Passphrase for details.
+func Passphrase(opts PassphraseOptions) (string, float64, error) {
+	return NewGenerator(rand.Reader).Passphrase(opts)
+}
+
+// Passphrase generates a phrase of random pronounceable words made by RandomWord, joined with separator.
+// It returns the phrase together with its entropy in bits, computed from the distribution RandomWord
+// actually uses: uniform word length, random first letter kind and 5 vowels or 21 consonants per letter.
+// Digits and symbols add log2(10) bits per character, plus the choice of the word they are appended to.
+// The estimate is exact as long as the separator is not empty and doesn't contain letters, digits or symbols
+// used in words, otherwise different choices may produce the same phrase and the real entropy is lower.
+// If MinEntropy is set, the number of words is increased until the entropy reaches it.
+// The blocklist of the generator makes the real entropy slightly lower, see DefaultBlocklist.
+func (g *Generator) Passphrase(opts PassphraseOptions) (string, float64, error) {
+	if opts.Words < 0 || opts.Digits < 0 || opts.Symbols < 0 {
+		return "", 0, fmt.Errorf("negative passphrase options: words %d, digits %d, symbols %d", opts.Words, opts.Digits, opts.Symbols)
+	}
+	if opts.Words == 0 {
+		opts.Words = passphraseDefaultWords
+	}
+	if opts.MinWordLen == 0 {
+		opts.MinWordLen = 4
+	}
+	if opts.MaxWordLen == 0 {
+		opts.MaxWordLen = 8
+	}
+	opts.MinWordLen = max(opts.MinWordLen, 2)
+	opts.MaxWordLen = max(opts.MaxWordLen, opts.MinWordLen)
+	if opts.Separator == "" {
+		opts.Separator = "-"
+	}
+
+	entropy := passphraseEntropy(opts)
+	for entropy < opts.MinEntropy {
+		if opts.Words >= passphraseMaxWords {
+			return "", 0, fmt.Errorf("can't reach entropy of %.1f bits with %d words", opts.MinEntropy, passphraseMaxWords)
+		}
+		opts.Words++
+		entropy = passphraseEntropy(opts)
+	}
+
+	words := make([]string, opts.Words)
+	for i := range words {
+		w, err := g.RandomWordE(opts.MinWordLen, opts.MaxWordLen)
+		if err != nil {
+			return "", 0, err
+		}
+		if opts.Capitalize {
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		words[i] = w
+	}
+
+	if err := g.appendRandom(words, "0123456789", opts.Digits); err != nil {
+		return "", 0, err
+	}
+	if err := g.appendRandom(words, passphraseSymbols, opts.Symbols); err != nil {
+		return "", 0, err
+	}
+
+	return strings.Join(words, opts.Separator), entropy, nil
+}
+
+// appendRandom appends n random characters from alphabet to a randomly chosen word
+func (g *Generator) appendRandom(words []string, alphabet string, n int) error {
+	if n == 0 {
+		return nil
+	}
+	idx, err := g.intn(len(words))
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, n)
+	for i := range buf {
+		var k int
+		if k, err = g.intn(len(alphabet)); err != nil {
+			return err
+		}
+		buf[i] = alphabet[k]
+	}
+	words[idx] += string(buf)
+	return nil
+}
+
+// passphraseEntropy returns entropy in bits of a phrase generated with normalized opts
+func passphraseEntropy(opts PassphraseOptions) float64 {
+	result := float64(opts.Words) * randomWordEntropy(opts.MinWordLen, opts.MaxWordLen)
+	if opts.Digits > 0 {
+		result += float64(opts.Digits)*math.Log2(10) + math.Log2(float64(opts.Words))
+	}
+	if opts.Symbols > 0 {
+		result += float64(opts.Symbols)*math.Log2(float64(len(passphraseSymbols))) + math.Log2(float64(opts.Words))
+	}
+	return result
+}
+
+// randomWordEntropy returns entropy in bits of a word made by RandomWord(minLen, maxLen), minLen >= 2.
+// Words of different lengths or starting with different letter kinds never coincide, so the entropy
+// is the sum of entropies of the length, the starting letter kind and the average entropy of letters.
+func randomWordEntropy(minLen, maxLen int) float64 {
+	vowel, consonant := math.Log2(5), math.Log2(21)
+	lengths := maxLen - minLen + 1
+	letters := 0.0
+	for n := minLen; n <= maxLen; n++ {
+		// either (n+1)/2 vowels and n/2 consonants or the other way, with equal probability
+		letters += float64(n) * (vowel + consonant) / 2
+	}
+	return math.Log2(float64(lengths)) + 1 + letters/float64(lengths)
+}
@@ -0,0 +1,107 @@
+package stringutils
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassphrase(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      PassphraseOptions
+		wantWords int
+		sep       string
+	}{
+		{"defaults", PassphraseOptions{}, 4, "-"},
+		{"custom words and separator", PassphraseOptions{Words: 6, Separator: " "}, 6, " "},
+		{"capitalized", PassphraseOptions{Words: 3, Capitalize: true, Separator: "."}, 3, "."},
+		{"digits and symbols", PassphraseOptions{Words: 3, Digits: 2, Symbols: 1}, 3, "-"},
+		{"min entropy adds words", PassphraseOptions{Words: 2, MinEntropy: 100}, 5, "-"},
+		{"min entropy already reached", PassphraseOptions{Words: 5, MinEntropy: 50}, 5, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phrase, entropy, err := NewSeededGenerator(1).Passphrase(tt.opts)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, entropy, tt.opts.MinEntropy)
+			words := strings.Split(phrase, tt.sep)
+			require.Len(t, words, tt.wantWords, phrase)
+
+			digits, symbols := 0, 0
+			for _, w := range words {
+				letters := strings.TrimRightFunc(w, func(r rune) bool { return !unicode.IsLetter(r) })
+				assert.GreaterOrEqual(t, len(letters), 4, phrase)
+				assert.LessOrEqual(t, len(letters), 8, phrase)
+				assert.Equal(t, tt.opts.Capitalize, unicode.IsUpper(rune(letters[0])), phrase)
+				for _, r := range w[len(letters):] {
+					if unicode.IsDigit(r) {
+						digits++
+						continue
+					}
+					assert.Contains(t, passphraseSymbols, string(r))
+					symbols++
+				}
+			}
+			assert.Equal(t, tt.opts.Digits, digits, phrase)
+			assert.Equal(t, tt.opts.Symbols, symbols, phrase)
+		})
+	}
+}
+
+func TestPassphrase_Entropy(t *testing.T) {
+	// two-letter words: 5*21 vowel-first and 21*5 consonant-first, all equally likely
+	_, entropy, err := NewSeededGenerator(1).Passphrase(PassphraseOptions{Words: 1, MinWordLen: 2, MaxWordLen: 2})
+	require.NoError(t, err)
+	assert.InDelta(t, math.Log2(210), entropy, 1e-9)
+
+	// each extra word multiplies the number of phrases
+	_, entropy3, err := NewSeededGenerator(1).Passphrase(PassphraseOptions{Words: 3, MinWordLen: 2, MaxWordLen: 2})
+	require.NoError(t, err)
+	assert.InDelta(t, 3*math.Log2(210), entropy3, 1e-9)
+
+	// lengths 2 and 3: 210 two-letter words and 5*21*5+21*5*21 three-letter words, both lengths equally likely
+	_, entropy, err = NewSeededGenerator(1).Passphrase(PassphraseOptions{Words: 1, MinWordLen: 2, MaxWordLen: 3})
+	require.NoError(t, err)
+	want := 1 + 0.5*math.Log2(210) + 0.5*(1+0.5*math.Log2(525)+0.5*math.Log2(2205))
+	assert.InDelta(t, want, entropy, 1e-9)
+
+	// digits add log2(10) per digit and choice of one of 3 words
+	_, entropyDigits, err := NewSeededGenerator(1).Passphrase(PassphraseOptions{Words: 3, MinWordLen: 2, MaxWordLen: 2, Digits: 2})
+	require.NoError(t, err)
+	assert.InDelta(t, entropy3+2*math.Log2(10)+math.Log2(3), entropyDigits, 1e-9)
+
+	// capitalization doesn't change entropy
+	_, entropyCap, err := NewSeededGenerator(1).Passphrase(PassphraseOptions{Words: 3, MinWordLen: 2, MaxWordLen: 2, Capitalize: true})
+	require.NoError(t, err)
+	assert.InDelta(t, entropy3, entropyCap, 1e-9)
+}
+
+func TestPassphrase_Errors(t *testing.T) {
+	_, _, err := Passphrase(PassphraseOptions{Words: -1})
+	require.Error(t, err)
+
+	_, _, err = Passphrase(PassphraseOptions{MinEntropy: 1e6})
+	require.Error(t, err)
+
+	_, _, err = NewGenerator(failingReader{err: errEntropy}).Passphrase(PassphraseOptions{})
+	require.ErrorIs(t, err, errEntropy)
+
+	phrase, entropy, err := Passphrase(PassphraseOptions{MinEntropy: 64})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, entropy, 64.0)
+	assert.NotEmpty(t, phrase)
+}
+
+func TestPassphrase_Seeded(t *testing.T) {
+	p1, _, err := NewSeededGenerator(5).Passphrase(PassphraseOptions{Digits: 1, Symbols: 1})
+	require.NoError(t, err)
+	p2, _, err := NewSeededGenerator(5).Passphrase(PassphraseOptions{Digits: 1, Symbols: 1})
+	require.NoError(t, err)
+	assert.Equal(t, p1, p2)
+}
@@ -0,0 +1,239 @@
+package stringutils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Weighted is a string with relative weight, used by Profile to make some letters and templates more frequent.
+// Zero weight is the same as 1.
+type Weighted struct {
+	Text   string
+	Weight int
+}
+
+// Profile defines phonotactics of generated words, i.e. which letters and syllables can make a word.
+// A word is built from syllables, each made by a random template. In a template "V" is a vowel, "C" is a
+// consonant, a run of two or more "C" is a single cluster from Clusters, and any other character is written as is,
+// e.g. "CV", "CVC", "CCV" or "CVn". Words containing any of Banned substrings or a syllable starting with any of
+// BannedSyllables are rejected and generated again.
+type Profile struct {
+	Name            string
+	Vowels          []Weighted
+	Consonants      []Weighted
+	Clusters        []Weighted // consonant clusters like "st" or "tr", required if any template has "CC"
+	Templates       []Weighted // syllable templates
+	Banned          []string   // banned bigrams or other substrings, checked on the whole word
+	BannedSyllables []string   // banned syllable beginnings, checked on each syllable, so "hu" doesn't ban "shu"
+}
+
+const profileMaxAttempts = 1000
+
+// EnglishProfile returns a profile making English-like words, with common clusters like "st" and "th"
+func EnglishProfile() Profile {
+	return Profile{
+		Name: "english",
+		Vowels: []Weighted{{"a", 8}, {"e", 10}, {"i", 7}, {"o", 7}, {"u", 3}, {"ee", 1}, {"ea", 1}, {"oo", 1},
+			{"ai", 1}, {"ou", 1}},
+		Consonants: []Weighted{{"b", 2}, {"c", 3}, {"d", 4}, {"f", 2}, {"g", 2}, {"h", 3}, {"j", 1}, {"k", 1},
+			{"l", 4}, {"m", 3}, {"n", 7}, {"p", 2}, {"r", 6}, {"s", 6}, {"t", 9}, {"v", 1}, {"w", 2}, {"y", 1}},
+		Clusters: []Weighted{{"st", 3}, {"th", 4}, {"sh", 2}, {"ch", 2}, {"tr", 2}, {"br", 1}, {"cr", 1}, {"gr", 1},
+			{"pl", 1}, {"bl", 1}, {"fl", 1}, {"sp", 1}, {"str", 1}},
+		Templates: []Weighted{{"CV", 4}, {"CVC", 5}, {"V", 1}, {"VC", 2}, {"CCV", 2}, {"CCVC", 1}},
+		Banned:    []string{"hh", "ww", "yy", "jj", "vv", "kk", "hc", "hg", "wh", "yc", "aee", "eee", "ooo", "uu"},
+	}
+}
+
+// ItalianProfile returns a profile making Italian-like words, mostly open syllables with double consonants
+func ItalianProfile() Profile {
+	return Profile{
+		Name:   "italian",
+		Vowels: []Weighted{{"a", 10}, {"e", 9}, {"i", 8}, {"o", 9}, {"u", 3}, {"ia", 1}, {"io", 1}},
+		Consonants: []Weighted{{"b", 2}, {"c", 4}, {"d", 3}, {"f", 2}, {"g", 2}, {"l", 5}, {"m", 3}, {"n", 5}, {"p", 3},
+			{"r", 5}, {"s", 4}, {"t", 5}, {"v", 2}, {"z", 1}},
+		Clusters: []Weighted{{"tr", 2}, {"pr", 2}, {"br", 1}, {"gr", 1}, {"st", 2}, {"sc", 1}, {"ch", 2}, {"gh", 1},
+			{"gl", 1}, {"gn", 1}, {"ll", 2}, {"tt", 2}, {"ss", 1}, {"nn", 1}, {"rr", 1}, {"zz", 1}, {"cc", 1}},
+		Templates: []Weighted{{"CV", 10}, {"CCV", 3}, {"V", 1}, {"CVn", 1}, {"CVr", 1}},
+		Banned:    []string{"ii", "uu", "aa", "iai", "iio", "oio", "nnn", "rrr", "chu", "cha", "cho", "gha", "gho", "ghu"},
+	}
+}
+
+// JapaneseProfile returns a profile making words like Japanese in Hepburn romanization
+func JapaneseProfile() Profile {
+	return Profile{
+		Name:   "japanese",
+		Vowels: []Weighted{{"a", 5}, {"i", 4}, {"u", 4}, {"e", 3}, {"o", 5}},
+		Consonants: []Weighted{{"k", 5}, {"s", 4}, {"t", 4}, {"n", 4}, {"h", 3}, {"m", 3}, {"y", 2}, {"r", 4}, {"w", 1},
+			{"g", 2}, {"z", 1}, {"d", 2}, {"b", 1}, {"p", 1}},
+		Clusters:  []Weighted{{"sh", 3}, {"ch", 2}, {"ts", 1}, {"ky", 1}, {"ry", 1}, {"ny", 1}, {"hy", 1}, {"my", 1}, {"gy", 1}},
+		Templates: []Weighted{{"CV", 10}, {"V", 2}, {"CVn", 2}, {"CCV", 2}},
+		Banned: []string{"yi", "ye", "wi", "wu", "we", "si", "ti", "tu", "zi", "di", "du",
+			"tsa", "tsi", "tse", "tso", "kyi", "kye", "ryi", "rye", "nyi", "nye", "hyi", "hye", "myi", "mye", "gyi", "gye"},
+		BannedSyllables: []string{"hu"}, // written as "fu", while "shu" and "chu" are fine
+	}
+}
+
+// ProfileWord generates a random word with length (in runes) between minLen and maxLen, following profile p.
+// It uses crypto/rand, see Generator.ProfileWord for details.
+func ProfileWord(p Profile, minLen, maxLen int) (string, error) {
+	return NewGenerator(rand.Reader).ProfileWord(p, minLen, maxLen)
+}
+
+// ProfileWord generates a random word with length (in runes) between minLen and maxLen, following profile p.
+// Syllables are added until the word reaches a random length within the range; words which are too long or
+// contain banned substrings are generated again. Returns error if the profile is invalid, the source of randomness
+// fails or no valid word is made after a number of attempts, e.g. if the range is too narrow for the templates.
+// RandomWord doesn't use profiles and keeps its own alternation of vowels and consonants.
+// The blocklist and the checker of the generator are applied to the word, in addition to banned substrings.
+func (g *Generator) ProfileWord(p Profile, minLen, maxLen int) (string, error) {
+	return g.filtered(func() (string, error) { return g.profileWord(p, minLen, maxLen) })
+}
+
+func (g *Generator) profileWord(p Profile, minLen, maxLen int) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	if minLen < 1 {
+		minLen = 1
+	}
+	if maxLen < minLen {
+		maxLen = minLen
+	}
+
+	banned := NewMatcher(p.Banned)
+	for attempt := 0; attempt < profileMaxAttempts; attempt++ {
+		n, err := g.intn(maxLen - minLen + 1)
+		if err != nil {
+			return "", err
+		}
+		target := minLen + n
+
+		var b strings.Builder
+		length, rejected := 0, false
+		for length < target && !rejected {
+			var syllable string
+			if syllable, err = g.syllable(p); err != nil {
+				return "", err
+			}
+			for _, prefix := range p.BannedSyllables {
+				rejected = rejected || strings.HasPrefix(syllable, prefix)
+			}
+			b.WriteString(syllable)
+			length += utf8.RuneCountInString(syllable)
+		}
+		if rejected || length > maxLen || banned.Match(b.String()) {
+			continue
+		}
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("can't make a word of %d-%d letters with profile %q in %d attempts",
+		minLen, maxLen, p.Name, profileMaxAttempts)
+}
+
+// syllable makes a syllable from a random template of the profile
+func (g *Generator) syllable(p Profile) (string, error) {
+	tmpl, err := g.pickWeighted(p.Templates)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i := 0; i < len(tmpl); {
+		kind, next := templateSlot(tmpl, i)
+		if kind == slotLiteral {
+			b.WriteString(tmpl[i:next])
+			i = next
+			continue
+		}
+		var s string
+		if s, err = g.pickWeighted(p.slotChoices(kind)); err != nil {
+			return "", err
+		}
+		b.WriteString(s)
+		i = next
+	}
+	return b.String(), nil
+}
+
+// pickWeighted returns text of a random item, with probability proportional to its weight
+func (g *Generator) pickWeighted(items []Weighted) (string, error) {
+	total := 0
+	for _, it := range items {
+		total += max(it.Weight, 1)
+	}
+	n, err := g.intn(total)
+	if err != nil {
+		return "", err
+	}
+	for _, it := range items {
+		if n -= max(it.Weight, 1); n < 0 {
+			return it.Text, nil
+		}
+	}
+	return items[len(items)-1].Text, nil
+}
+
+// kinds of template slots
+const (
+	slotLiteral = iota
+	slotVowel
+	slotConsonant
+	slotCluster
+)
+
+// templateSlot returns kind of the template slot starting at byte i and index of the next slot
+func templateSlot(tmpl string, i int) (kind, next int) {
+	switch tmpl[i] {
+	case 'V':
+		return slotVowel, i + 1
+	case 'C':
+		next = i + 1
+		for next < len(tmpl) && tmpl[next] == 'C' {
+			next++
+		}
+		if next-i > 1 {
+			return slotCluster, next
+		}
+		return slotConsonant, next
+	}
+	_, size := utf8.DecodeRuneInString(tmpl[i:])
+	return slotLiteral, i + size
+}
+
+func (p Profile) slotChoices(kind int) []Weighted {
+	switch kind {
+	case slotVowel:
+		return p.Vowels
+	case slotConsonant:
+		return p.Consonants
+	case slotCluster:
+		return p.Clusters
+	}
+	return nil
+}
+
+// validate checks that every template of the profile can be filled
+func (p Profile) validate() error {
+	if len(p.Templates) == 0 {
+		return fmt.Errorf("profile %q has no templates", p.Name)
+	}
+	for _, items := range [][]Weighted{p.Vowels, p.Consonants, p.Clusters, p.Templates} {
+		for _, it := range items {
+			if it.Weight < 0 || it.Text == "" {
+				return fmt.Errorf("profile %q has invalid item %+v", p.Name, it)
+			}
+		}
+	}
+	names := map[int]string{slotVowel: "vowels", slotConsonant: "consonants", slotCluster: "clusters"}
+	for _, t := range p.Templates {
+		for i := 0; i < len(t.Text); {
+			kind, next := templateSlot(t.Text, i)
+			if kind != slotLiteral && len(p.slotChoices(kind)) == 0 {
+				return fmt.Errorf("profile %q has no %s for template %q", p.Name, names[kind], t.Text)
+			}
+			i = next
+		}
+	}
+	return nil
+}
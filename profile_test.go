@@ -0,0 +1,136 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_ProfileWord(t *testing.T) {
+	for _, p := range []Profile{EnglishProfile(), ItalianProfile(), JapaneseProfile()} {
+		t.Run(p.Name, func(t *testing.T) {
+			gen := NewSeededGenerator(1)
+			seen := map[string]bool{}
+			for i := 0; i < 200; i++ {
+				w, err := gen.ProfileWord(p, 4, 9)
+				require.NoError(t, err)
+				n := utf8.RuneCountInString(w)
+				require.GreaterOrEqual(t, n, 4, w)
+				require.LessOrEqual(t, n, 9, w)
+				for _, b := range p.Banned {
+					require.NotContains(t, w, b)
+				}
+				seen[w] = true
+			}
+			assert.Greater(t, len(seen), 150, "words should be varied")
+		})
+	}
+}
+
+func TestGenerator_ProfileWordJapaneseHu(t *testing.T) {
+	gen := NewSeededGenerator(1)
+	var shu, chu int
+	for i := 0; i < 2000; i++ {
+		w, err := gen.ProfileWord(JapaneseProfile(), 4, 9)
+		require.NoError(t, err)
+		for k := 0; k+2 <= len(w); k++ {
+			if w[k:k+2] != "hu" {
+				continue
+			}
+			require.True(t, k > 0 && (w[k-1] == 's' || w[k-1] == 'c'), "%q has a syllable hu", w)
+			if w[k-1] == 's' {
+				shu++
+			} else {
+				chu++
+			}
+		}
+	}
+	assert.Positive(t, shu, "shu is produced")
+	assert.Positive(t, chu, "chu is produced")
+}
+
+func TestGenerator_ProfileWordCustom(t *testing.T) {
+	p := Profile{
+		Name:       "custom",
+		Vowels:     []Weighted{{Text: "a"}},
+		Consonants: []Weighted{{Text: "b"}},
+		Clusters:   []Weighted{{Text: "st"}},
+		Templates:  []Weighted{{Text: "CV"}},
+	}
+	w, err := NewSeededGenerator(1).ProfileWord(p, 6, 6)
+	require.NoError(t, err)
+	assert.Equal(t, "bababa", w)
+
+	p.Templates = []Weighted{{Text: "CCVn"}}
+	w, err = NewSeededGenerator(1).ProfileWord(p, 8, 8)
+	require.NoError(t, err)
+	assert.Equal(t, "stanstan", w, "run of C is a cluster, lowercase letters are literal")
+
+	p.Templates = []Weighted{{Text: "CV"}, {Text: "V"}}
+	p.Banned = []string{"aa"}
+	gen := NewSeededGenerator(1)
+	for i := 0; i < 20; i++ {
+		w, err = gen.ProfileWord(p, 5, 5)
+		require.NoError(t, err)
+		assert.NotContains(t, w, "aa", "banned bigrams are never produced")
+	}
+	p.Banned = []string{"aa", "ba"}
+	_, err = NewSeededGenerator(1).ProfileWord(p, 2, 2)
+	require.Error(t, err, "2 letters can't be made without banned bigrams")
+
+	p.Banned = nil
+	p.Templates = []Weighted{{Text: "CV"}, {Text: "CCV"}}
+	p.Clusters = []Weighted{{Text: "sb"}}
+	p.BannedSyllables = []string{"ba"}
+	for i := 0; i < 20; i++ {
+		w, err = gen.ProfileWord(p, 6, 6)
+		require.NoError(t, err)
+		assert.Equal(t, "sbasba", w, "banned syllable is not banned inside another syllable")
+	}
+}
+
+func TestGenerator_ProfileWordWeights(t *testing.T) {
+	p := Profile{
+		Name:      "weights",
+		Vowels:    []Weighted{{Text: "a", Weight: 9}, {Text: "e", Weight: 1}},
+		Templates: []Weighted{{Text: "V"}},
+	}
+	w, err := NewSeededGenerator(3).ProfileWord(p, 1000, 1000)
+	require.NoError(t, err)
+	a, e := strings.Count(w, "a"), strings.Count(w, "e")
+	assert.Equal(t, 1000, a+e)
+	assert.InDelta(t, 900, a, 60)
+}
+
+func TestGenerator_ProfileWordErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Profile
+	}{
+		{"no templates", Profile{Vowels: []Weighted{{Text: "a"}}}},
+		{"no vowels", Profile{Consonants: []Weighted{{Text: "b"}}, Templates: []Weighted{{Text: "CV"}}}},
+		{"no consonants", Profile{Vowels: []Weighted{{Text: "a"}}, Clusters: []Weighted{{Text: "st"}},
+			Templates: []Weighted{{Text: "CCVC"}}}},
+		{"no clusters", Profile{Vowels: []Weighted{{Text: "a"}}, Consonants: []Weighted{{Text: "b"}},
+			Templates: []Weighted{{Text: "CCV"}}}},
+		{"negative weight", Profile{Vowels: []Weighted{{Text: "a", Weight: -1}}, Templates: []Weighted{{Text: "V"}}}},
+		{"empty text", Profile{Vowels: []Weighted{{Text: ""}}, Templates: []Weighted{{Text: "V"}}}},
+		{"too narrow range", Profile{Vowels: []Weighted{{Text: "a"}}, Templates: []Weighted{{Text: "VVV"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSeededGenerator(1).ProfileWord(tt.p, 2, 2)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := NewGenerator(failingReader{err: errEntropy}).ProfileWord(EnglishProfile(), 4, 8)
+	require.ErrorIs(t, err, errEntropy)
+
+	w, err := ProfileWord(ItalianProfile(), 5, 8)
+	require.NoError(t, err)
+	assert.NotEmpty(t, w)
+}
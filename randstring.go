@@ -0,0 +1,115 @@
+package stringutils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+)
+
+// predefined alphabets for RandomString
+const (
+	AlphabetDigits       = "0123456789"
+	AlphabetHex          = "0123456789abcdef"
+	AlphabetCrockford    = "0123456789ABCDEFGHJKMNPQRSTVWXYZ" // base32 by Douglas Crockford, no I, L, O and U
+	AlphabetBase64URL    = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	AlphabetAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	AlphabetUnambiguous  = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz" // no 0/O, 1/l/I
+)
+
+const randomStringMaxAttempts = 1000
+
+// RandomString generates a string of length characters (runes) picked uniformly from alphabet, using crypto/rand.
+// See Generator.RandomString for details.
+func RandomString(length int, alphabet string) (string, error) {
+	return NewGenerator(rand.Reader).RandomString(length, alphabet)
+}
+
+// RandomStringClasses generates a string of length characters with at least one character from each class,
+// using crypto/rand. See Generator.RandomStringClasses for details.
+func RandomStringClasses(length int, classes ...string) (string, error) {
+	return NewGenerator(rand.Reader).RandomStringClasses(length, classes...)
+}
+
+// RandomString generates a string of length characters (runes) picked uniformly from alphabet.
+// Every character is chosen with rejection sampling, so there is no modulo bias for alphabets of any size.
+// Returns error if length is negative, alphabet is empty or has duplicate characters, or the source of randomness fails.
+func (g *Generator) RandomString(length int, alphabet string) (string, error) {
+	if length < 0 {
+		return "", fmt.Errorf("negative length %d", length)
+	}
+	chars, err := alphabetRunes(alphabet)
+	if err != nil {
+		return "", err
+	}
+	return g.randomRunes(length, chars)
+}
+
+// RandomStringClasses generates a string of length characters (runes) picked uniformly from the union of classes,
+// with at least one character from each class, e.g. RandomStringClasses(12, "abc...z", "ABC...Z", "0123456789").
+// Strings missing a class are rejected and generated again, so every valid string is equally likely.
+// Returns error if a class is empty or invalid, length is less than the number of classes, the source of randomness
+// fails or no valid string is made after a number of attempts, which is possible only for very unbalanced classes.
+func (g *Generator) RandomStringClasses(length int, classes ...string) (string, error) {
+	if len(classes) == 0 {
+		return "", fmt.Errorf("no classes")
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("length %d is less than number of classes %d", length, len(classes))
+	}
+	var all strings.Builder
+	for _, c := range classes {
+		if _, err := alphabetRunes(c); err != nil {
+			return "", err
+		}
+		all.WriteString(c)
+	}
+	chars := NewSet([]rune(all.String())...).Items() // classes may overlap, each character is taken once
+
+	for attempt := 0; attempt < randomStringMaxAttempts; attempt++ {
+		s, err := g.randomRunes(length, chars)
+		if err != nil {
+			return "", err
+		}
+		if hasAllClasses(s, classes) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("can't make a string with all %d classes in %d attempts", len(classes), randomStringMaxAttempts)
+}
+
+func (g *Generator) randomRunes(length int, chars []rune) (string, error) {
+	result := make([]rune, length)
+	for i := range result {
+		n, err := g.intn(len(chars))
+		if err != nil {
+			return "", err
+		}
+		result[i] = chars[n]
+	}
+	return string(result), nil
+}
+
+// alphabetRunes returns characters of alphabet, it must be non-empty and without duplicates
+func alphabetRunes(alphabet string) ([]rune, error) {
+	if alphabet == "" {
+		return nil, fmt.Errorf("empty alphabet")
+	}
+	chars := []rune(alphabet)
+	seen := make(map[rune]bool, len(chars))
+	for _, r := range chars {
+		if seen[r] {
+			return nil, fmt.Errorf("duplicate character %q in alphabet %q", r, alphabet)
+		}
+		seen[r] = true
+	}
+	return chars, nil
+}
+
+func hasAllClasses(s string, classes []string) bool {
+	for _, c := range classes {
+		if !strings.ContainsAny(s, c) {
+			return false
+		}
+	}
+	return true
+}
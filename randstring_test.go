@@ -0,0 +1,97 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomString(t *testing.T) {
+	alphabets := map[string]string{
+		"digits":       AlphabetDigits,
+		"hex":          AlphabetHex,
+		"crockford":    AlphabetCrockford,
+		"base64url":    AlphabetBase64URL,
+		"alphanumeric": AlphabetAlphanumeric,
+		"unambiguous":  AlphabetUnambiguous,
+		"cyrillic":     "абвгд",
+	}
+	for name, alphabet := range alphabets {
+		t.Run(name, func(t *testing.T) {
+			s, err := RandomString(32, alphabet)
+			require.NoError(t, err)
+			assert.Equal(t, 32, utf8.RuneCountInString(s))
+			for _, r := range s {
+				assert.Contains(t, alphabet, string(r))
+			}
+		})
+	}
+
+	assert.Len(t, AlphabetCrockford, 32)
+	assert.Len(t, AlphabetBase64URL, 64)
+	assert.Len(t, AlphabetHex, 16)
+	assert.False(t, strings.ContainsAny(AlphabetUnambiguous, "0O1lI"))
+
+	s, err := RandomString(0, AlphabetHex)
+	require.NoError(t, err)
+	assert.Empty(t, s)
+}
+
+func TestRandomString_Errors(t *testing.T) {
+	_, err := RandomString(-1, AlphabetHex)
+	require.Error(t, err)
+	_, err = RandomString(5, "")
+	require.Error(t, err)
+	_, err = RandomString(5, "abca")
+	require.Error(t, err, "duplicates would bias the distribution")
+	_, err = NewGenerator(failingReader{err: errEntropy}).RandomString(5, AlphabetHex)
+	require.ErrorIs(t, err, errEntropy)
+}
+
+func TestRandomString_Uniform(t *testing.T) {
+	// 3 characters need 2 bits, without rejection of the 4th value the first character would be twice as likely
+	s, err := NewSeededGenerator(1).RandomString(30000, "abc")
+	require.NoError(t, err)
+	for _, c := range "abc" {
+		assert.InDelta(t, 10000, strings.Count(s, string(c)), 400, "character %q", c)
+	}
+}
+
+func TestRandomString_Seeded(t *testing.T) {
+	s1, err := NewSeededGenerator(1).RandomString(16, AlphabetCrockford)
+	require.NoError(t, err)
+	s2, err := NewSeededGenerator(1).RandomString(16, AlphabetCrockford)
+	require.NoError(t, err)
+	assert.Equal(t, s1, s2)
+}
+
+func TestRandomStringClasses(t *testing.T) {
+	classes := []string{"abcdefghijklmnopqrstuvwxyz", "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "0123456789", "!@#"}
+	gen := NewSeededGenerator(1)
+	for i := 0; i < 100; i++ {
+		s, err := gen.RandomStringClasses(4, classes...)
+		require.NoError(t, err)
+		require.Len(t, s, 4)
+		for _, c := range classes {
+			require.True(t, strings.ContainsAny(s, c), "%q has no character from %q", s, c)
+		}
+	}
+
+	s, err := RandomStringClasses(10, "ab", "bc")
+	require.NoError(t, err, "overlapping classes")
+	assert.Len(t, s, 10)
+
+	_, err = RandomStringClasses(2, classes...)
+	require.Error(t, err, "too short for all classes")
+	_, err = RandomStringClasses(5)
+	require.Error(t, err, "no classes")
+	_, err = RandomStringClasses(5, "abc", "")
+	require.Error(t, err, "empty class")
+	_, err = RandomStringClasses(2, AlphabetAlphanumeric+AlphabetBase64URL[:62], "!")
+	require.Error(t, err, "duplicates in a class")
+	_, err = NewGenerator(failingReader{err: errEntropy}).RandomStringClasses(5, "abc", "123")
+	require.ErrorIs(t, err, errEntropy)
+}
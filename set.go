@@ -0,0 +1,187 @@
+package stringutils
+
+import "iter"
+
+// Set is a set of comparable values which remembers insertion order.
+// Iteration, Items and the results of set operations follow the order in which values were first added.
+// The zero value is an empty set ready to use. Set is not safe for concurrent modification.
+type Set[T comparable] struct {
+	index   map[T]int // position of the value in order
+	order   []T       // values in insertion order, may contain stale entries of removed values
+	removed int       // number of stale entries in order
+}
+
+// NewSet makes a set with the given items
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{index: make(map[T]int, len(items)), order: make([]T, 0, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// Add adds items to the set. Items already in the set keep their original position.
+func (s *Set[T]) Add(items ...T) {
+	if s.index == nil {
+		s.index = make(map[T]int, len(items))
+	}
+	for _, v := range items {
+		if _, ok := s.index[v]; ok {
+			continue
+		}
+		s.index[v] = len(s.order)
+		s.order = append(s.order, v)
+	}
+}
+
+// Remove removes items from the set
+func (s *Set[T]) Remove(items ...T) {
+	if s == nil || len(s.index) == 0 {
+		return
+	}
+	for _, v := range items {
+		if _, ok := s.index[v]; ok {
+			delete(s.index, v)
+			s.removed++
+		}
+	}
+	if s.removed > len(s.order)/2 {
+		s.compact()
+	}
+}
+
+// Has checks if item is in the set
+func (s *Set[T]) Has(item T) bool {
+	if s == nil {
+		return false
+	}
+	_, ok := s.index[item]
+	return ok
+}
+
+// Len returns number of items in the set
+func (s *Set[T]) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.index)
+}
+
+// Items returns items of the set in insertion order, nil for empty set
+func (s *Set[T]) Items() []T {
+	if s.Len() == 0 {
+		return nil
+	}
+	result := make([]T, 0, s.Len())
+	for v := range s.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// All returns an iterator over items of the set in insertion order
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if s == nil {
+			return
+		}
+		for i, v := range s.order {
+			if pos, ok := s.index[v]; !ok || pos != i {
+				continue // stale entry of a removed value
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new set with items of both sets, items of s go first
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for v := range s.All() {
+		result.Add(v)
+	}
+	for v := range other.All() {
+		result.Add(v)
+	}
+	return result
+}
+
+// Intersect returns a new set with items present in both sets, in order of s
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for v := range s.All() {
+		if other.Has(v) {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// Difference returns a new set with items of s which are not in other
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for v := range s.All() {
+		if !other.Has(v) {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set with items present in exactly one of the sets,
+// items of s go first
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	result := s.Difference(other)
+	for v := range other.All() {
+		if !s.Has(v) {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// IsSubset checks if every item of s is in other
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for v := range s.All() {
+		if !other.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset checks if every item of other is in s
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal checks if both sets have the same items, regardless of order
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// appendNew appends items missing in seen to dst and adds them to seen. Slice functions use it
+// to build their results directly, without allocating a Set and copying its items.
+func appendNew[T comparable](dst []T, seen map[T]struct{}, items ...T) []T {
+	for _, v := range items {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// compact drops stale entries of removed values from order
+func (s *Set[T]) compact() {
+	order := make([]T, 0, len(s.index))
+	for v := range s.All() {
+		s.index[v] = len(order)
+		order = append(order, v)
+	}
+	s.order = order
+	s.removed = 0
+}
@@ -0,0 +1,160 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_Basic(t *testing.T) {
+	s := NewSet("b", "a", "c", "a")
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []string{"b", "a", "c"}, s.Items())
+	assert.True(t, s.Has("a"))
+	assert.False(t, s.Has("d"))
+
+	s.Add("d", "b")
+	assert.Equal(t, []string{"b", "a", "c", "d"}, s.Items())
+
+	s.Remove("a", "missing")
+	assert.Equal(t, 3, s.Len())
+	assert.False(t, s.Has("a"))
+	assert.Equal(t, []string{"b", "c", "d"}, s.Items())
+
+	s.Add("a") // re-added value goes to the end
+	assert.Equal(t, []string{"b", "c", "d", "a"}, s.Items())
+
+	s.Remove("b", "c", "d", "a")
+	assert.Equal(t, 0, s.Len())
+	assert.Nil(t, s.Items())
+}
+
+func TestSet_ZeroAndNil(t *testing.T) {
+	var zero Set[int]
+	assert.Equal(t, 0, zero.Len())
+	assert.False(t, zero.Has(1))
+	zero.Remove(1)
+	zero.Add(3, 1, 2)
+	assert.Equal(t, []int{3, 1, 2}, zero.Items())
+
+	var nilSet *Set[int]
+	assert.Equal(t, 0, nilSet.Len())
+	assert.False(t, nilSet.Has(1))
+	assert.Nil(t, nilSet.Items())
+	nilSet.Remove(1)
+	assert.Equal(t, []int{1, 2}, NewSet(1, 2).Union(nilSet).Items())
+	assert.True(t, nilSet.IsSubset(NewSet(1)))
+}
+
+func TestSet_RemoveCompaction(t *testing.T) {
+	s := NewSet[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+	for i := 0; i < 100; i += 2 {
+		s.Remove(i)
+	}
+	for i := 0; i < 90; i += 3 {
+		s.Remove(i)
+		s.Add(i)
+	}
+	assert.Equal(t, 65, s.Len())
+	items := s.Items()
+	assert.Len(t, items, 65)
+	assert.Equal(t, 1, items[0])
+	assert.Equal(t, 87, items[len(items)-1])
+	for _, v := range items {
+		assert.True(t, s.Has(v))
+	}
+	assert.LessOrEqual(t, len(s.order), 2*s.Len()+1)
+}
+
+func TestSet_All(t *testing.T) {
+	s := NewSet("x", "y", "z")
+	s.Remove("y")
+	var got []string
+	for v := range s.All() {
+		got = append(got, v)
+	}
+	assert.Equal(t, []string{"x", "z"}, got)
+
+	got = nil
+	for v := range s.All() {
+		got = append(got, v)
+		break
+	}
+	assert.Equal(t, []string{"x"}, got)
+}
+
+func TestSet_Operations(t *testing.T) {
+	a := NewSet("a", "b", "c", "d")
+	b := NewSet("e", "d", "b")
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, a.Union(b).Items())
+	assert.Equal(t, []string{"b", "d"}, a.Intersect(b).Items())
+	assert.Equal(t, []string{"d", "b"}, b.Intersect(a).Items())
+	assert.Equal(t, []string{"a", "c"}, a.Difference(b).Items())
+	assert.Equal(t, []string{"e"}, b.Difference(a).Items())
+	assert.Equal(t, []string{"a", "c", "e"}, a.SymmetricDifference(b).Items())
+	assert.Nil(t, a.Difference(a).Items())
+
+	// operations don't mutate operands
+	assert.Equal(t, []string{"a", "b", "c", "d"}, a.Items())
+	assert.Equal(t, []string{"e", "d", "b"}, b.Items())
+}
+
+func TestSet_Relations(t *testing.T) {
+	tests := []struct {
+		name                      string
+		a, b                      *Set[string]
+		subset, superset, isEqual bool
+	}{
+		{"equal sets different order", NewSet("a", "b"), NewSet("b", "a"), true, true, true},
+		{"proper subset", NewSet("a"), NewSet("a", "b"), true, false, false},
+		{"proper superset", NewSet("a", "b", "c"), NewSet("c"), false, true, false},
+		{"disjoint", NewSet("a"), NewSet("b"), false, false, false},
+		{"same size different items", NewSet("a", "b"), NewSet("a", "c"), false, false, false},
+		{"both empty", NewSet[string](), NewSet[string](), true, true, true},
+		{"empty is subset", NewSet[string](), NewSet("a"), true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.subset, tt.a.IsSubset(tt.b))
+			assert.Equal(t, tt.superset, tt.a.IsSuperset(tt.b))
+			assert.Equal(t, tt.isEqual, tt.a.Equal(tt.b))
+		})
+	}
+}
+
+func BenchmarkSet_Has(b *testing.B) {
+	ref := make([]string, 1000)
+	for i := range ref {
+		ref[i] = RandomWord(6, 10)
+	}
+	probe := []string{"missing", ref[500], "other"}
+
+	b.Run("set reused", func(b *testing.B) {
+		set := NewSet(ref...)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			for _, p := range probe {
+				_ = set.Has(p)
+			}
+		}
+	})
+
+	b.Run("HasCommonElement", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = HasCommonElement(ref, probe)
+		}
+	})
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	a := []string{"a", "b", "a", "c", "b", "d", "e", "a"}
+	other := []string{"e", "c", "x", "a"}
+	for i := 0; i < b.N; i++ {
+		_ = Intersection(a, other)
+	}
+}
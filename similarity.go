@@ -0,0 +1,173 @@
+package stringutils
+
+import "math"
+
+// Similarity returns similarity score of two strings in [0, 1], 1 for equal strings and 0 for completely different ones.
+// Jaro, JaroWinkler and functions returned by NewJaroWinkler, NGramJaccard, NGramDice and NGramCosine are Similarity.
+type Similarity func(a, b string) float64
+
+// Jaro returns Jaro similarity of a and b, based on the number of matching runes which are not farther apart
+// than half of the longer string, and the number of transpositions among them. Two empty strings are equal.
+func Jaro(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(max(len(ra), len(rb))/2-1, 0)
+	matchedA, matchedB := make([]bool, len(ra)), make([]bool, len(rb))
+	matches := 0
+	for i, r := range ra {
+		for j := max(0, i-window); j <= min(len(rb)-1, i+window); j++ {
+			if !matchedB[j] && rb[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// count matched runes which are in different order, each transposition is counted twice
+	transpositions, j := 0, 0
+	for i, r := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if r != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions/2))/m) / 3
+}
+
+// JaroWinkler returns Jaro-Winkler similarity of a and b, which is Jaro similarity boosted for strings with
+// a common prefix of up to 4 runes, with the standard prefix scale 0.1
+func JaroWinkler(a, b string) float64 {
+	return jaroWinkler(a, b, 0.1)
+}
+
+// NewJaroWinkler returns Jaro-Winkler similarity with the given prefix scale, the weight of each common prefix rune.
+// The scale is limited to [0, 0.25] to keep the score in [0, 1], 0 makes it the same as Jaro.
+func NewJaroWinkler(scale float64) Similarity {
+	scale = min(max(scale, 0), 0.25)
+	return func(a, b string) float64 { return jaroWinkler(a, b, scale) }
+}
+
+func jaroWinkler(a, b string, scale float64) float64 {
+	const maxPrefix = 4
+	sim := Jaro(a, b)
+	prefix := 0
+	ra, rb := []rune(a), []rune(b)
+	for prefix < min(len(ra), len(rb), maxPrefix) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*scale*(1-sim)
+}
+
+// NGramJaccard returns similarity of strings as Jaccard index of their sets of character n-grams,
+// the number of common n-grams divided by the number of distinct n-grams of both strings.
+// A string shorter than n is a single n-gram, n less than 1 is treated as 1.
+func NGramJaccard(n int) Similarity {
+	return func(a, b string) float64 {
+		ga, gb := ngrams(a, n), ngrams(b, n)
+		if len(ga) == 0 && len(gb) == 0 {
+			return 1
+		}
+		common := ngramsCommon(ga, gb)
+		return float64(common) / float64(len(ga)+len(gb)-common)
+	}
+}
+
+// NGramDice returns similarity of strings as Sørensen-Dice coefficient of their sets of character n-grams,
+// twice the number of common n-grams divided by the total number of distinct n-grams in each string.
+// A string shorter than n is a single n-gram, n less than 1 is treated as 1.
+func NGramDice(n int) Similarity {
+	return func(a, b string) float64 {
+		ga, gb := ngrams(a, n), ngrams(b, n)
+		if len(ga) == 0 && len(gb) == 0 {
+			return 1
+		}
+		return 2 * float64(ngramsCommon(ga, gb)) / float64(len(ga)+len(gb))
+	}
+}
+
+// NGramCosine returns cosine similarity of vectors of character n-gram counts of strings, so unlike
+// NGramJaccard and NGramDice repeated n-grams matter. A string shorter than n is a single n-gram,
+// n less than 1 is treated as 1.
+func NGramCosine(n int) Similarity {
+	return func(a, b string) float64 {
+		ga, gb := ngrams(a, n), ngrams(b, n)
+		if len(ga) == 0 && len(gb) == 0 {
+			return 1
+		}
+		var dot, normA, normB float64
+		for g, ca := range ga {
+			dot += float64(ca * gb[g])
+			normA += float64(ca * ca)
+		}
+		for _, cb := range gb {
+			normB += float64(cb * cb)
+		}
+		if dot == 0 {
+			return 0
+		}
+		return min(dot/math.Sqrt(normA*normB), 1)
+	}
+}
+
+// ContainsFuzzy checks if slice contains a string with similarity to src at least threshold
+func ContainsFuzzy(src string, inSlice []string, sim Similarity, threshold float64) bool {
+	return IndexOfFuzzy(inSlice, src, sim, threshold) >= 0
+}
+
+// IndexOfFuzzy returns the index of the first string in slice with similarity to element at least threshold,
+// or -1 if not found. Use ClosestMatch to find the most similar string instead of the first one.
+func IndexOfFuzzy(slice []string, element string, sim Similarity, threshold float64) int {
+	for i, s := range slice {
+		if sim(s, element) >= threshold {
+			return i
+		}
+	}
+	return -1
+}
+
+// ngrams returns counts of character n-grams of s
+func ngrams(s string, n int) map[string]int {
+	n = max(n, 1)
+	runes := []rune(s)
+	result := make(map[string]int, max(len(runes)-n+1, 1))
+	if len(runes) == 0 {
+		return result
+	}
+	if len(runes) <= n {
+		result[s]++
+		return result
+	}
+	for i := 0; i+n <= len(runes); i++ {
+		result[string(runes[i:i+n])]++
+	}
+	return result
+}
+
+// ngramsCommon returns number of distinct n-grams present in both sets
+func ngramsCommon(ga, gb map[string]int) int {
+	common := 0
+	for g := range ga {
+		if _, ok := gb[g]; ok {
+			common++
+		}
+	}
+	return common
+}
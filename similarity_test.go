@@ -0,0 +1,101 @@
+package stringutils
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		jaro, jw float64
+	}{
+		{"", "", 1, 1},
+		{"abc", "", 0, 0},
+		{"abc", "abc", 1, 1},
+		{"abc", "xyz", 0, 0},
+		{"MARTHA", "MARHTA", 0.944444, 0.961111},
+		{"DWAYNE", "DUANE", 0.822222, 0.84},
+		{"DIXON", "DICKSONX", 0.766667, 0.813333},
+		{"CRATE", "TRACE", 0.733333, 0.733333},
+		{"привет", "привте", 0.944444, 0.966667},
+		{"a", "a", 1, 1},
+		{"a", "b", 0, 0},
+		{"a", "ab", 0.833333, 0.85},
+		{"b", "ab", 0, 0},
+		{"ab", "ba", 0, 0},
+		{"ж", "ж", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"|"+tt.b, func(t *testing.T) {
+			assert.InDelta(t, tt.jaro, Jaro(tt.a, tt.b), 1e-6, "jaro")
+			assert.InDelta(t, tt.jaro, Jaro(tt.b, tt.a), 1e-6, "jaro is symmetric")
+			assert.InDelta(t, tt.jw, JaroWinkler(tt.a, tt.b), 1e-6, "jaro-winkler")
+			assert.InDelta(t, tt.jaro, NewJaroWinkler(0)(tt.a, tt.b), 1e-6, "zero scale is jaro")
+		})
+	}
+
+	// bigger scale boosts common prefix more, but never above 1
+	assert.Greater(t, NewJaroWinkler(0.2)("MARTHA", "MARHTA"), JaroWinkler("MARTHA", "MARHTA"))
+	assert.LessOrEqual(t, NewJaroWinkler(5)("abcdx", "abcdy"), 1.0)
+}
+
+func TestNGramSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		sim  Similarity
+		a, b string
+		want float64
+	}{
+		{"jaccard bigrams", NGramJaccard(2), "night", "nacht", 1.0 / 7},
+		{"jaccard equal", NGramJaccard(2), "night", "night", 1},
+		{"jaccard both empty", NGramJaccard(2), "", "", 1},
+		{"jaccard one empty", NGramJaccard(2), "abc", "", 0},
+		{"jaccard shorter than n", NGramJaccard(3), "ab", "ab", 1},
+		{"jaccard unigrams", NGramJaccard(0), "abc", "bcd", 0.5},
+		{"dice bigrams", NGramDice(2), "night", "nacht", 0.25},
+		{"dice unicode", NGramDice(2), "привет", "привед", 0.8},
+		{"dice disjoint", NGramDice(2), "abc", "xyz", 0},
+		{"cosine repeated", NGramCosine(2), "abab", "ab", 2 / math.Sqrt(5)},
+		{"cosine counts scale", NGramCosine(1), "aa", "aaa", 1},
+		{"cosine disjoint", NGramCosine(2), "abc", "xyz", 0},
+		{"cosine one empty", NGramCosine(2), "", "xyz", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.sim(tt.a, tt.b), 1e-9)
+			assert.InDelta(t, tt.want, tt.sim(tt.b, tt.a), 1e-9, "symmetric")
+		})
+	}
+}
+
+func TestFuzzy(t *testing.T) {
+	names := []string{"Jonathan Smith", "Jon Smyth", "Mary Jones"}
+	tests := []struct {
+		name      string
+		s         string
+		sim       Similarity
+		threshold float64
+		want      int
+	}{
+		{"jaro-winkler typo", "Mary Jnoes", JaroWinkler, 0.9, 2},
+		{"first above threshold", "Jon Smith", JaroWinkler, 0.8, 0},
+		{"strict threshold", "Jon Smith", JaroWinkler, 0.95, 1},
+		{"nothing similar", "Peter Parker", NGramDice(2), 0.5, -1},
+		{"exact with threshold 1", "Mary Jones", NGramCosine(2), 1, 2},
+		{"jaro custom scale", "Jon Smyht", NewJaroWinkler(0.2), 0.95, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IndexOfFuzzy(names, tt.s, tt.sim, tt.threshold))
+			assert.Equal(t, tt.want >= 0, ContainsFuzzy(tt.s, names, tt.sim, tt.threshold))
+		})
+	}
+	assert.Equal(t, -1, IndexOfFuzzy(nil, "abc", Jaro, 0))
+	assert.True(t, ContainsFuzzy("a", []string{"a"}, JaroWinkler, 0.9), "one rune strings")
+	assert.Equal(t, 1, IndexOfFuzzy([]string{"b", "a"}, "a", Jaro, 0.9))
+}
@@ -0,0 +1,131 @@
+package stringutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// SlugOptions defines parameters of SlugifyWith
+type SlugOptions struct {
+	Separator string   // put between words, "-" if empty
+	MaxLen    int      // maximum length in runes, cut at a word boundary if possible, no limit if 0 or less
+	StopWords []string // words to drop, like "a" or "the", kept if there are no other words
+	Fallback  string   // returned as is if the slug is empty, a short hash of the input is used if not set
+}
+
+// slugTransliteration has ASCII replacements for lowercase letters which can't be made ASCII by removing
+// diacritics, and German umlauts, which have their own rules. Other Latin letters lose diacritics.
+var slugTransliteration = map[rune]string{ //nolint:gochecknoglobals // read-only transliteration table
+	// Latin
+	'ß': "ss", 'ä': "ae", 'ö': "oe", 'ü': "ue", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th",
+	'ł': "l", 'ı': "i", 'ħ': "h", 'ŧ': "t", 'ŋ': "ng", 'ſ': "s",
+	// Cyrillic, Russian, Ukrainian, Belarusian, Serbian and Macedonian letters
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "u", 'ђ': "dj", 'ј': "j",
+	'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+	// Greek, accented vowels lose their accents first
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Slugify makes a URL and filename safe slug of s, lowercase ASCII letters and digits separated by "-".
+// See SlugifyWith for details.
+func Slugify(s string) string {
+	return SlugifyWith(s, SlugOptions{})
+}
+
+// SlugifyWith makes a slug of s, lowercase ASCII letters and digits separated by opts.Separator.
+// Latin letters lose diacritics ("crème" is "creme"), German umlauts and ß follow German rules ("straße über" is
+// "strasse-ueber"), and Cyrillic and Greek letters are transliterated ("привет" is "privet"). Apostrophes are dropped
+// and all other characters separate words, so scripts without transliteration, like CJK, are dropped as well.
+// With opts.MaxLen the slug is cut at a word boundary, or inside the first word if it is longer than MaxLen.
+// The result is never empty: if no words are left, returns opts.Fallback or a hash of s in hex.
+func SlugifyWith(s string, opts SlugOptions) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+
+	words := slugWords(s)
+	if len(opts.StopWords) > 0 {
+		stop := NewSet(slugWords(strings.Join(opts.StopWords, " "))...)
+		var kept []string
+		for _, w := range words {
+			if !stop.Has(w) {
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) > 0 {
+			words = kept
+		}
+	}
+
+	slug := strings.Join(words, sep)
+	if opts.MaxLen > 0 {
+		slug = truncateSlug(words, sep, opts.MaxLen)
+	}
+	if slug != "" {
+		return slug
+	}
+	if opts.Fallback != "" {
+		return opts.Fallback
+	}
+	sum := sha256.Sum256([]byte(s))
+	hash := hex.EncodeToString(sum[:4])
+	if opts.MaxLen > 0 && len(hash) > opts.MaxLen {
+		hash = hash[:opts.MaxLen]
+	}
+	return hash
+}
+
+// slugWords returns transliterated lowercase words of s made of ASCII letters and digits
+func slugWords(s string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '\'' || r == '’':
+			continue
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+			continue
+		}
+		if t, ok := slugTransliteration[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		for _, d := range norm.NFD.String(string(r)) {
+			if unicode.Is(unicode.Mn, d) {
+				continue
+			}
+			if t, ok := slugTransliteration[d]; ok {
+				b.WriteString(t)
+				continue
+			}
+			b.WriteRune(d)
+		}
+	}
+	return strings.FieldsFunc(b.String(), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+}
+
+// truncateSlug joins words with sep, cut to maxLen runes with TruncateWith at a word boundary
+func truncateSlug(words []string, sep string, maxLen int) string {
+	joined := strings.Join(words, " ")
+	// separator may be longer than the space, so shorten the limit until the slug fits
+	for limit := maxLen; limit > 0; limit-- {
+		cut := TruncateWith(joined, TruncateOptions{MaxLen: limit, WordBoundary: true, NoEllipsis: true})
+		if slug := strings.ReplaceAll(cut, " ", sep); utf8.RuneCountInString(slug) <= maxLen {
+			return slug
+		}
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"  multiple   spaces\tand\nnewlines ", "multiple-spaces-and-newlines"},
+		{"Crème brûlée à la française", "creme-brulee-a-la-francaise"},
+		{"Straße über Äpfel und Öl", "strasse-ueber-aepfel-und-oel"},
+		{"Łódź, Ærø, Þórshöfn", "lodz-aero-thorshoefn"},
+		{"Привет, мир!", "privet-mir"},
+		{"Щука и ёж", "shchuka-i-yozh"},
+		{"Їжак і ґанок", "yizhak-i-ganok"},
+		{"Καλημέρα κόσμε", "kalimera-kosme"},
+		{"ΣΟΦΊΑ", "sofia"},
+		{"Don't stop me now", "dont-stop-me-now"},
+		{"Go 1.23 released", "go-1-23-released"},
+		{"snake_case/and.dots", "snake-case-and-dots"},
+		{"中文 title", "title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slugify(tt.input))
+		})
+	}
+}
+
+func TestSlugifyWith(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  SlugOptions
+		want  string
+	}{
+		{"separator", "Hello World", SlugOptions{Separator: "_"}, "hello_world"},
+		{"max len at word boundary", "the quick brown fox", SlugOptions{MaxLen: 12}, "the-quick"},
+		{"max len exact", "the quick brown fox", SlugOptions{MaxLen: 15}, "the-quick-brown"},
+		{"max len fits", "quick fox", SlugOptions{MaxLen: 20}, "quick-fox"},
+		{"max len long first word", "supercalifragilistic word", SlugOptions{MaxLen: 5}, "super"},
+		{"max len long separator", "one two three", SlugOptions{Separator: "--", MaxLen: 9}, "one--two"},
+		{"max len transliterated", "Привет мир", SlugOptions{MaxLen: 8}, "privet"},
+		{"stop words", "The Quick Fox and a Dog", SlugOptions{StopWords: []string{"the", "A", "and"}}, "quick-fox-dog"},
+		{"stop words transliterated", "Über alles", SlugOptions{StopWords: []string{"über"}}, "alles"},
+		{"only stop words", "The A", SlugOptions{StopWords: []string{"the", "a"}}, "the-a"},
+		{"fallback", "!!!", SlugOptions{Fallback: "untitled"}, "untitled"},
+		{"fallback not used", "ok", SlugOptions{Fallback: "untitled"}, "ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SlugifyWith(tt.input, tt.opts))
+		})
+	}
+}
+
+func TestSlugify_NeverEmpty(t *testing.T) {
+	for _, s := range []string{"", "   ", "!!!", "中文", "日本語のタイトル"} {
+		got := Slugify(s)
+		assert.Regexp(t, "^[0-9a-f]{8}$", got, "input %q", s)
+		assert.Equal(t, got, Slugify(s), "stable for %q", s)
+	}
+	assert.NotEqual(t, Slugify("中文"), Slugify("日本語"))
+	assert.Equal(t, Slugify("中文")[:4], SlugifyWith("中文", SlugOptions{MaxLen: 4}))
+}
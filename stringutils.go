@@ -4,7 +4,6 @@ package stringutils
 import (
 	"crypto/rand"
 	"fmt"
-	"math/big"
 	"strings"
 )
 
@@ -18,8 +17,29 @@ func Contains(src string, inSlice []string) bool {
 	return false
 }
 
-// ContainsAnySubstring checks if string contains any of provided substring
+// ContainsAnySubstring checks if string contains any of provided substring.
+// Empty substrings are skipped. For repeated checks against the same substrings
+// compile them once with NewMatcher and use Matcher.Match.
 func ContainsAnySubstring(s string, subStrings []string) bool {
+	if !matcherPaysOff(s, subStrings) {
+		return containsEach(s, subStrings)
+	}
+	return NewMatcher(subStrings).Match(s)
+}
+
+// matcherPaysOff estimates if compiling subStrings into a Matcher and scanning s once is cheaper
+// than searching for every substring separately. It is for long inputs and many substrings,
+// as strings.Contains is much faster per byte than the matcher and needs no compilation.
+func matcherPaysOff(s string, subStrings []string) bool {
+	size := 0
+	for _, sub := range subStrings {
+		size += len(sub)
+	}
+	return len(s)*len(subStrings) > 128*size+8*len(s)+16384
+}
+
+// containsEach checks substrings one by one with strings.Contains, skipping empty ones
+func containsEach(s string, subStrings []string) bool {
 	for _, mx := range subStrings {
 		if mx == "" {
 			continue // skip empty substrings
@@ -37,15 +57,7 @@ func DeDup(keys []string) []string {
 	if len(keys) == 0 {
 		return nil
 	}
-	result := make([]string, 0, len(keys))
-	visited := make(map[string]struct{}, len(keys))
-	for _, k := range keys {
-		if _, found := visited[k]; !found {
-			visited[k] = struct{}{}
-			result = append(result, k)
-		}
-	}
-	return result
+	return appendNew(make([]string, 0, len(keys)), make(map[string]struct{}, len(keys)), keys...)
 }
 
 // DeDupBig remove duplicates from slice.
@@ -80,12 +92,9 @@ func HasCommonElement(a, b []string) bool {
 	if len(a) > len(b) {
 		a, b = b, a
 	}
-	set := make(map[string]struct{}, len(a))
-	for _, x := range a {
-		set[x] = struct{}{}
-	}
+	set := NewSet(a...)
 	for _, y := range b {
-		if _, ok := set[y]; ok {
+		if set.Has(y) {
 			return true
 		}
 	}
@@ -114,70 +123,28 @@ func HasSuffixSlice(suffix string, slice []string) bool {
 
 // Truncate cuts string to the given length (in runes) and adds ellipsis if it was truncated
 // if maxLen is less than 4 (3 chars for ellipsis + 1 rune from string), returns empty string
+// See TruncateWith for custom ellipsis, cut position and word boundary options.
 func Truncate(s string, maxLen int) string {
-	if maxLen < 4 {
-		return ""
-	}
-
-	runes := []rune(s)
-	if len(runes) <= maxLen {
-		return s
-	}
-
-	return string(runes[:maxLen-3]) + "..."
+	return TruncateWith(s, TruncateOptions{MaxLen: maxLen})
 }
 
 // TruncateWords cuts string to the given number of words and adds ellipsis if it was truncated
-// returns empty string if maxWords is 0
+// returns empty string if maxWords is less than 1
 func TruncateWords(s string, maxWords int) string {
-	if maxWords == 0 {
-		return ""
-	}
-
-	words := strings.Fields(s)
-	if len(words) <= maxWords {
-		return s
-	}
-
-	return strings.Join(words[:maxWords], " ") + "..."
+	return TruncateWith(s, TruncateOptions{MaxLen: maxWords, Unit: UnitWords})
 }
 
-// RandomWord generates pronounceable random word with length between minLen and maxLen
+// RandomWord generates pronounceable random word with length between minLen and maxLen.
+// It uses crypto/rand, see Generator for a seeded or custom source of randomness.
+// It panics if crypto/rand fails, instead of returning a predictable word; use RandomWordE to handle the error.
 func RandomWord(minLen, maxLen int) string {
-	if minLen < 2 {
-		minLen = 2
-	}
-	if maxLen < minLen {
-		maxLen = minLen
-	}
-
-	vowels := []rune("aeiou")
-	consonants := []rune("bcdfghjklmnpqrstvwxyz")
-
-	// make a random length between min and max
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(maxLen-minLen+1)))
-	length := minLen
-	if err == nil {
-		length += int(n.Int64())
-	}
-
-	var result strings.Builder
-	// decide to start with vowel or consonant
-	n, _ = rand.Int(rand.Reader, big.NewInt(2))
-	startWithVowel := n.Int64() == 0
-
-	for i := 0; i < length; i++ {
-		isVowel := (i%2 == 0) == startWithVowel
-		if isVowel {
-			n, _ = rand.Int(rand.Reader, big.NewInt(int64(len(vowels))))
-			result.WriteRune(vowels[n.Int64()])
-		} else {
-			n, _ = rand.Int(rand.Reader, big.NewInt(int64(len(consonants))))
-			result.WriteRune(consonants[n.Int64()])
-		}
-	}
+	return NewGenerator(rand.Reader).RandomWord(minLen, maxLen)
+}
 
-	return result.String()
+// RandomWordE generates pronounceable random word with length between minLen and maxLen using crypto/rand.
+// Returns error if crypto/rand fails.
+func RandomWordE(minLen, maxLen int) (string, error) {
+	return NewGenerator(rand.Reader).RandomWordE(minLen, maxLen)
 }
 
 // Filter returns a new slice containing only elements that match the predicate
@@ -250,15 +217,11 @@ func Difference(a, b []string) []string {
 		return a
 	}
 
-	// build set from b for O(1) lookups
-	bSet := make(map[string]struct{}, len(b))
-	for _, s := range b {
-		bSet[s] = struct{}{}
-	}
-
+	// build set from b for O(1) lookups, duplicates in a are kept
+	bSet := NewSet(b...)
 	result := make([]string, 0, len(a))
 	for _, s := range a {
-		if _, found := bSet[s]; !found {
+		if !bSet.Has(s) {
 			result = append(result, s)
 		}
 	}
@@ -274,29 +237,10 @@ func Union(slices ...[]string) []string {
 		return nil
 	}
 
-	// estimate capacity
-	totalLen := 0
-	for _, slice := range slices {
-		totalLen += len(slice)
-	}
-	if totalLen == 0 {
-		return nil
-	}
-
-	seen := make(map[string]struct{}, totalLen)
-	result := make([]string, 0, totalLen)
-
+	seen := make(map[string]struct{})
+	var result []string
 	for _, slice := range slices {
-		for _, s := range slice {
-			if _, found := seen[s]; !found {
-				seen[s] = struct{}{}
-				result = append(result, s)
-			}
-		}
-	}
-
-	if len(result) == 0 {
-		return nil
+		result = appendNew(result, seen, slice...)
 	}
 	return result
 }
@@ -307,30 +251,26 @@ func Intersection(a, b []string) []string {
 		return nil
 	}
 
-	// build set from b for O(1) lookups
+	// plain maps for lookups, a Set would allocate and fill its order slice as well
 	bSet := make(map[string]struct{}, len(b))
 	for _, s := range b {
 		bSet[s] = struct{}{}
 	}
-
 	result := make([]string, 0, len(a))
 	seen := make(map[string]struct{}, len(a))
 	for _, s := range a {
-		if _, inB := bSet[s]; inB {
-			if _, alreadyAdded := seen[s]; !alreadyAdded {
-				seen[s] = struct{}{}
-				result = append(result, s)
-			}
+		if _, ok := bSet[s]; ok {
+			result = appendNew(result, seen, s)
 		}
 	}
-
 	if len(result) == 0 {
 		return nil
 	}
 	return result
 }
 
-// NormalizeWhitespace replaces multiple whitespace characters with single space and trims
+// NormalizeWhitespace replaces multiple whitespace characters with single space and trims.
+// Use NormalizeWhitespaceWith to treat invisible characters like zero-width space as whitespace too.
 func NormalizeWhitespace(s string) string {
 	if s == "" {
 		return ""
@@ -344,7 +284,8 @@ func NormalizeWhitespace(s string) string {
 	return strings.Join(fields, " ")
 }
 
-// IsBlank returns true if string is empty or contains only whitespace
+// IsBlank returns true if string is empty or contains only whitespace.
+// Use IsBlankWith to treat invisible characters like zero-width space as whitespace too.
 func IsBlank(s string) bool {
 	return strings.TrimSpace(s) == ""
 }
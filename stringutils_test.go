@@ -356,6 +356,18 @@ func TestTruncate(t *testing.T) {
 			maxLen: 6, // changed from 4 since we should only truncate if it won't fit
 			want:   "👋🌍✨",
 		},
+		{
+			name:   "invalid utf-8 bytes replaced",
+			input:  "\xff\xfehello world",
+			maxLen: 6,
+			want:   "\ufffd\ufffdh...",
+		},
+		{
+			name:   "invalid utf-8 kept if fits",
+			input:  "\xffhello",
+			maxLen: 6,
+			want:   "\xffhello",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,206 @@
+package stringutils
+
+import (
+	"sort"
+	"unicode"
+)
+
+// FuzzyResult is a string matched by MatchFuzzy or RankFuzzy
+type FuzzyResult struct {
+	Value     string
+	Index     int   // index of the value in the slice passed to RankFuzzy, 0 for MatchFuzzy
+	Score     int   // higher is better
+	Positions []int // indexes of matched runes in the value, for highlighting
+}
+
+// scoring of subsequence matches, bonuses are added for matched runes in the marked positions
+const (
+	fzScoreMatch        = 16
+	fzGapStart          = -3
+	fzGapExtension      = -1
+	fzBonusBoundary     = fzScoreMatch / 2                 // word start after punctuation
+	fzBonusWhite        = fzBonusBoundary + 2              // word start after whitespace or at the beginning
+	fzBonusDelimiter    = fzBonusBoundary + 1              // word start after path separator or other delimiter
+	fzBonusNonWord      = fzScoreMatch / 2                 // punctuation itself
+	fzBonusCamel        = fzBonusBoundary + fzGapExtension // camelCase hump or start of a number
+	fzBonusConsecutive  = -(fzGapStart + fzGapExtension)   // rune right after the previous matched one
+	fzFirstCharMultiply = 2                                // the first rune of query gets its bonus doubled
+	fzNoMatch           = -1 << 30                         // score of impossible alignment
+)
+
+type fzCharClass int
+
+const (
+	fzWhite fzCharClass = iota
+	fzNonWord
+	fzDelimiter
+	fzLower
+	fzUpper
+	fzLetter
+	fzNumber
+)
+
+// MatchFuzzy checks if all runes of query are present in target in the same order, not necessarily adjacent,
+// like in fzf or Sublime Text, so "fbr" matches "FooBar.go". Among all ways to match it picks the one with the best
+// score, which rewards word starts, camelCase humps, runes after path separators and consecutive runs, and penalizes
+// gaps. Matching is case-insensitive unless query has uppercase letters (smart case).
+// Empty query matches any target with zero score.
+func MatchFuzzy(query, target string) (FuzzyResult, bool) {
+	q, t := []rune(query), []rune(target)
+	caseSensitive := false
+	for _, r := range q {
+		if unicode.IsUpper(r) {
+			caseSensitive = true
+			break
+		}
+	}
+	tm := t // target runes to compare with query
+	if !caseSensitive {
+		q, tm = runesToLower(q), runesToLower(t)
+	}
+
+	if len(q) == 0 {
+		return FuzzyResult{Value: target}, true
+	}
+	if !isSubsequence(q, tm) {
+		return FuzzyResult{}, false
+	}
+
+	bonus := make([]int, len(t))
+	prev := fzWhite
+	for j, r := range t {
+		cur := fzClassOf(r)
+		bonus[j] = fzBonusFor(prev, cur)
+		prev = cur
+	}
+
+	// score[i][j] is the best score of matching q[:i+1] with q[i] matched at t[j],
+	// from[i][j] is the position of q[i-1] in that match and chunk[i][j] is the bonus of the first rune of
+	// the run of consecutive matched runes ending at j
+	n := len(t)
+	score, from, chunk := make([][]int, len(q)), make([][]int, len(q)), make([][]int, len(q))
+	for i := range q {
+		score[i], from[i], chunk[i] = make([]int, n), make([]int, n), make([]int, n)
+		bestGap, bestGapFrom := fzNoMatch, -1 // best score[i-1][k] with gap penalty for k < j-1
+		for j := 0; j < n; j++ {
+			if i > 0 && j >= 2 {
+				if v := score[i-1][j-2] + fzGapStart; v > bestGap+fzGapExtension {
+					bestGap, bestGapFrom = v, j-2
+				} else {
+					bestGap += fzGapExtension
+				}
+			}
+			score[i][j] = fzNoMatch
+			if q[i] != tm[j] {
+				continue
+			}
+			if i == 0 {
+				score[i][j], from[i][j], chunk[i][j] = fzScoreMatch+bonus[j]*fzFirstCharMultiply, -1, bonus[j]
+				continue
+			}
+			if bestGapFrom >= 0 && bestGap > fzNoMatch/2 {
+				score[i][j], from[i][j], chunk[i][j] = bestGap+fzScoreMatch+bonus[j], bestGapFrom, bonus[j]
+			}
+			if j > 0 && score[i-1][j-1] > fzNoMatch/2 {
+				b := max(bonus[j], chunk[i-1][j-1], fzBonusConsecutive)
+				if v := score[i-1][j-1] + fzScoreMatch + b; v >= score[i][j] {
+					score[i][j], from[i][j], chunk[i][j] = v, j-1, max(chunk[i-1][j-1], bonus[j])
+				}
+			}
+		}
+	}
+
+	last := len(q) - 1
+	best := -1
+	for j := 0; j < n; j++ {
+		if score[last][j] > fzNoMatch/2 && (best < 0 || score[last][j] > score[last][best]) {
+			best = j
+		}
+	}
+	positions := make([]int, len(q))
+	for i, j := last, best; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return FuzzyResult{Value: target, Score: score[last][best], Positions: positions}, true
+}
+
+// RankFuzzy returns targets matching query as MatchFuzzy does, sorted by score from the best.
+// Equal scores are ordered by length, the shorter first, and then by the order in targets.
+func RankFuzzy(query string, targets []string) []FuzzyResult {
+	var result []FuzzyResult
+	for i, t := range targets {
+		if m, ok := MatchFuzzy(query, t); ok {
+			m.Index = i
+			result = append(result, m)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return len(result[i].Value) < len(result[j].Value)
+	})
+	return result
+}
+
+func fzClassOf(r rune) fzCharClass {
+	switch {
+	case unicode.IsLower(r):
+		return fzLower
+	case unicode.IsUpper(r):
+		return fzUpper
+	case unicode.IsNumber(r):
+		return fzNumber
+	case unicode.IsLetter(r):
+		return fzLetter
+	case unicode.IsSpace(r):
+		return fzWhite
+	case r == '/' || r == '\\' || r == ',' || r == ':' || r == ';' || r == '|':
+		return fzDelimiter
+	}
+	return fzNonWord
+}
+
+// fzBonusFor returns bonus for a rune of class cur after a rune of class prev
+func fzBonusFor(prev, cur fzCharClass) int {
+	isWord := func(c fzCharClass) bool { return c >= fzLower }
+	if isWord(cur) {
+		switch prev {
+		case fzWhite:
+			return fzBonusWhite
+		case fzDelimiter:
+			return fzBonusDelimiter
+		case fzNonWord:
+			return fzBonusBoundary
+		}
+	}
+	if prev == fzLower && cur == fzUpper || prev != fzNumber && cur == fzNumber {
+		return fzBonusCamel
+	}
+	switch cur {
+	case fzNonWord, fzDelimiter:
+		return fzBonusNonWord
+	case fzWhite:
+		return fzBonusWhite
+	}
+	return 0
+}
+
+func isSubsequence(q, t []rune) bool {
+	i := 0
+	for _, r := range t {
+		if i < len(q) && q[i] == r {
+			i++
+		}
+	}
+	return i == len(q)
+}
+
+func runesToLower(r []rune) []rune {
+	result := make([]rune, len(r))
+	for i, c := range r {
+		result[i] = unicode.ToLower(c)
+	}
+	return result
+}
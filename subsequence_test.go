@@ -0,0 +1,81 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchFuzzy(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		target    string
+		ok        bool
+		positions []int
+	}{
+		{"camel case", "fbr", "FooBar.go", true, []int{0, 3, 5}},
+		{"word starts preferred", "fb", "xfoo fbar", true, []int{5, 6}},
+		{"path separator", "sug", "src/utils/generator.go", true, []int{0, 4, 10}},
+		{"consecutive preferred", "bar", "b_a_r bar", true, []int{6, 7, 8}},
+		{"not in order", "rab", "FooBar", false, nil},
+		{"missing rune", "fbz", "FooBar", false, nil},
+		{"smart case sensitive", "FB", "foobar", false, nil},
+		{"smart case upper matches", "FB", "FooBar", true, []int{0, 3}},
+		{"unicode", "пм", "привет мир", true, []int{0, 7}},
+		{"empty query", "", "anything", true, nil},
+		{"empty target", "a", "", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, ok := MatchFuzzy(tt.query, tt.target)
+			require.Equal(t, tt.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.target, res.Value)
+			assert.Equal(t, tt.positions, res.Positions)
+		})
+	}
+}
+
+func TestMatchFuzzy_Score(t *testing.T) {
+	score := func(query, target string) int {
+		res, ok := MatchFuzzy(query, target)
+		require.True(t, ok, "%q in %q", query, target)
+		return res.Score
+	}
+	assert.Equal(t, 68, score("fbr", "FooBar.go"))
+	assert.Greater(t, score("fb", "foo_bar"), score("fb", "foobar"), "word start bonus")
+	assert.Greater(t, score("fb", "fooBar"), score("fb", "foobar"), "camel case bonus")
+	assert.Greater(t, score("fb", "foo/bar"), score("fb", "foo_bar"), "path separator bonus")
+	assert.Greater(t, score("abc", "abcxyz"), score("abc", "axbxcx"), "consecutive bonus")
+	assert.Greater(t, score("oo", "foo"), score("oo", "fxoxo"), "shorter gap")
+	assert.Equal(t, 0, score("", "foo"))
+}
+
+func TestRankFuzzy(t *testing.T) {
+	files := []string{"internal/fuzzy_bar.go", "FooBar.go", "fbr", "foo/bar/readme.md", "unrelated.txt", "f-b-r.go"}
+	res := RankFuzzy("fbr", files)
+	require.Len(t, res, 5)
+	values := make([]string, len(res))
+	for i, r := range res {
+		values[i] = r.Value
+		assert.Equal(t, r.Value, files[r.Index])
+		if i > 0 {
+			assert.GreaterOrEqual(t, res[i-1].Score, r.Score)
+		}
+	}
+	assert.Equal(t, "fbr", values[0], "exact consecutive match is the best")
+	assert.NotContains(t, values, "unrelated.txt")
+
+	all := RankFuzzy("", []string{"b", "a"})
+	assert.Equal(t, []FuzzyResult{{Value: "b", Index: 0}, {Value: "a", Index: 1}}, all, "empty query keeps order")
+	assert.Nil(t, RankFuzzy("x", nil))
+
+	// equal scores go by length, then by original order
+	res = RankFuzzy("ab", []string{"ab-long", "ab", "ab-long"})
+	assert.Equal(t, []int{1, 0, 2}, []int{res[0].Index, res[1].Index, res[2].Index})
+}
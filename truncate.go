@@ -0,0 +1,308 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TruncateUnit defines how TruncateWith measures length of a string
+type TruncateUnit int
+
+const (
+	// UnitRunes measures length in runes, as Truncate does
+	UnitRunes TruncateUnit = iota
+	// UnitGraphemes measures length in grapheme clusters, as TruncateGraphemes does
+	UnitGraphemes
+	// UnitWidth measures length in terminal cells, as TruncateWidth does
+	UnitWidth
+	// UnitWords measures length in whitespace separated words, as TruncateWords does.
+	// Kept words are joined with single spaces and the ellipsis is not counted in the limit.
+	UnitWords
+	// UnitBytes measures length in bytes, as TruncateBytes does. Multi-byte UTF-8 sequences are never split.
+	UnitBytes
+	// UnitUnicodeWords measures length in words found by Unicode word boundaries, as TruncateWordsUnicode does.
+	// Text between kept words is preserved and the ellipsis is not counted in the limit.
+	UnitUnicodeWords
+)
+
+// TruncatePosition defines which part of a string TruncateWith cuts out
+type TruncatePosition int
+
+const (
+	// TruncateEnd keeps the beginning of the string, "hello w..."
+	TruncateEnd TruncatePosition = iota
+	// TruncateStart keeps the end of the string, "...o world"
+	TruncateStart
+	// TruncateMiddle keeps both the beginning and the end of the string, "hell...orld"
+	TruncateMiddle
+)
+
+// TruncateOptions defines parameters of TruncateWith
+type TruncateOptions struct {
+	MaxLen        int              // maximum length in units, including the ellipsis for all units except word units
+	Unit          TruncateUnit     // unit of MaxLen, runes by default
+	Position      TruncatePosition // where to cut, end of string by default
+	Ellipsis      string           // marker put in place of the removed part, "..." if empty
+	NoEllipsis    bool             // cut without any marker, Ellipsis is ignored
+	WordBoundary  bool             // prefer to cut at whitespace instead of in the middle of a word
+	KeepGraphemes bool             // never split grapheme clusters, for UnitRunes and UnitBytes
+}
+
+// TruncateWith cuts string to opts.MaxLen and puts an ellipsis in place of the removed part.
+// Returns s unchanged if it fits. For all units except word units the ellipsis counts toward the limit,
+// and if MaxLen can't fit the ellipsis plus at least one rune or grapheme cluster from string, returns empty string.
+// For word units returns empty string if MaxLen is less than 1.
+// Invalid UTF-8 bytes in the kept part of a cut string become U+FFFD, as they always did in Truncate,
+// except for UnitBytes where they are kept as is to stay within the byte budget.
+func TruncateWith(s string, opts TruncateOptions) string {
+	ellipsis := opts.Ellipsis
+	if ellipsis == "" {
+		ellipsis = "..."
+	}
+	if opts.NoEllipsis {
+		ellipsis = ""
+	}
+
+	switch opts.Unit {
+	case UnitWords:
+		return truncateWords(s, opts.MaxLen, opts.Position, ellipsis)
+	case UnitUnicodeWords:
+		return truncateUnicodeWords(s, opts.MaxLen, opts.Position, ellipsis)
+	}
+
+	budget := opts.MaxLen - measure(ellipsis, opts.Unit)
+	if budget < 1 {
+		return ""
+	}
+	if fits(s, opts.MaxLen, opts.Unit, opts.KeepGraphemes) {
+		return s
+	}
+
+	head, tail := 0, len(s)
+	switch opts.Position {
+	case TruncateStart:
+		segs := segments(s, opts.Unit, opts.KeepGraphemes)
+		tail = tailStart(s, segs, budget, opts.WordBoundary)
+	case TruncateMiddle:
+		segs := segments(s, opts.Unit, opts.KeepGraphemes)
+		head = headEnd(s, sliceSegmenter(segs), budget-budget/2, opts.WordBoundary)
+		tail = tailStart(s, segs, budget/2, opts.WordBoundary)
+	default:
+		// only the head is needed, so segments are made lazily up to the cut point
+		sg := &segmenter{s: s, unit: opts.Unit, keepGraphemes: opts.KeepGraphemes}
+		head = headEnd(s, sg.next, budget, opts.WordBoundary)
+	}
+	if head == 0 && tail == len(s) {
+		return "" // not a single unit fits, e.g. a multi-byte rune for UnitBytes
+	}
+	if opts.Unit == UnitBytes {
+		return s[:head] + ellipsis + s[tail:] // replacement characters would not fit the byte budget
+	}
+	return validRunes(s[:head]) + ellipsis + validRunes(s[tail:])
+}
+
+// validRunes replaces every invalid UTF-8 byte with U+FFFD, same as conversion through []rune does
+func validRunes(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// segment is an indivisible part of a string for truncation
+type segment struct {
+	start, end int  // byte offsets in the string
+	size       int  // size in truncation units
+	space      bool // segment is whitespace
+}
+
+// segmenter splits a string into runes or grapheme clusters with their sizes in the given unit, one at a time.
+// Grapheme clusters are used for UnitGraphemes and UnitWidth, and for other units if keepGraphemes is set.
+type segmenter struct {
+	s             string
+	unit          TruncateUnit
+	keepGraphemes bool
+	pos           int // byte offset of the next segment
+}
+
+// next returns the next segment, false if there are no more
+func (sg *segmenter) next() (segment, bool) {
+	if sg.pos >= len(sg.s) {
+		return segment{}, false
+	}
+	pos := sg.pos
+	r, n := utf8.DecodeRuneInString(sg.s[pos:])
+	if sg.keepGraphemes || sg.unit == UnitGraphemes || sg.unit == UnitWidth {
+		n = nextGrapheme(sg.s[pos:])
+	}
+	size := 1
+	switch sg.unit {
+	case UnitWidth:
+		size = graphemeWidth(sg.s[pos : pos+n])
+	case UnitBytes:
+		size = n
+	case UnitRunes:
+		size = utf8.RuneCountInString(sg.s[pos : pos+n])
+	}
+	sg.pos += n
+	return segment{start: pos, end: pos + n, size: size, space: unicode.IsSpace(r)}, true
+}
+
+// segments returns all segments of s, see segmenter
+func segments(s string, unit TruncateUnit, keepGraphemes bool) []segment {
+	var result []segment
+	sg := &segmenter{s: s, unit: unit, keepGraphemes: keepGraphemes}
+	for seg, ok := sg.next(); ok; seg, ok = sg.next() {
+		result = append(result, seg)
+	}
+	return result
+}
+
+// sliceSegmenter returns function giving segments from segs one at a time, like segmenter.next
+func sliceSegmenter(segs []segment) func() (segment, bool) {
+	i := 0
+	return func() (segment, bool) {
+		if i >= len(segs) {
+			return segment{}, false
+		}
+		i++
+		return segs[i-1], true
+	}
+}
+
+// fits checks if length of s in the given unit is not more than maxLen, measuring only as much of s as needed
+func fits(s string, maxLen int, unit TruncateUnit, keepGraphemes bool) bool {
+	if len(s) <= maxLen {
+		return true // no rune or grapheme cluster is longer or wider than its bytes
+	}
+	switch unit {
+	case UnitBytes:
+		return false
+	case UnitRunes:
+		count := 0
+		for range s {
+			if count++; count > maxLen {
+				return false
+			}
+		}
+		return true
+	}
+	total := 0
+	sg := &segmenter{s: s, unit: unit, keepGraphemes: keepGraphemes}
+	for seg, ok := sg.next(); ok; seg, ok = sg.next() {
+		if total += seg.size; total > maxLen {
+			return false
+		}
+	}
+	return true
+}
+
+// measure returns length of s in the given unit
+func measure(s string, unit TruncateUnit) int {
+	switch unit {
+	case UnitGraphemes:
+		return GraphemeCount(s)
+	case UnitWidth:
+		return StringWidth(s)
+	case UnitBytes:
+		return len(s)
+	default:
+		return utf8.RuneCountInString(s)
+	}
+}
+
+// headEnd returns byte offset where the longest prefix of segments fitting into budget ends, taking segments
+// from next until the first one not fitting. With wordBoundary the prefix is shortened to the last whitespace,
+// if there is one, and trailing spaces are dropped. If only leading whitespace is left, the cut is made
+// inside the word, as for a single long word.
+func headEnd(s string, next func() (segment, bool), budget int, wordBoundary bool) int {
+	end, used, count := 0, 0, 0
+	lastSpace := -1 // start of the last whitespace segment except the first segment
+	seg, ok := next()
+	for ok && used+seg.size <= budget {
+		if seg.space && count > 0 {
+			lastSpace = seg.start
+		}
+		used += seg.size
+		end = seg.end
+		count++
+		seg, ok = next()
+	}
+	if count == 0 || !wordBoundary {
+		return end
+	}
+	hard := end
+	if ok && !seg.space && lastSpace >= 0 {
+		end = lastSpace
+	}
+	if end = len(strings.TrimRightFunc(s[:end], unicode.IsSpace)); end == 0 {
+		return hard
+	}
+	return end
+}
+
+// tailStart returns byte offset where the longest suffix of segments fitting into budget starts.
+// With wordBoundary the suffix is shortened to the first whitespace, if there is one, and leading spaces are dropped.
+// If only trailing whitespace is left, the cut is made inside the word, as for a single long word.
+func tailStart(s string, segs []segment, budget int, wordBoundary bool) int {
+	n, used := len(segs), 0
+	for n > 0 && used+segs[n-1].size <= budget {
+		used += segs[n-1].size
+		n--
+	}
+	if n == len(segs) {
+		return len(s)
+	}
+	hard := segs[n].start
+	if !wordBoundary {
+		return hard
+	}
+	if n > 0 && !segs[n-1].space {
+		for i := n; i < len(segs)-1; i++ {
+			if segs[i].space {
+				n = i + 1
+				break
+			}
+		}
+	}
+	start := len(s) - len(strings.TrimLeftFunc(s[segs[n].start:], unicode.IsSpace))
+	if start == len(s) {
+		return hard
+	}
+	return start
+}
+
+// TruncateBytes cuts string to the given length in bytes and adds ellipsis if it was truncated.
+// The result, including the ellipsis, never exceeds maxBytes and multi-byte UTF-8 sequences are never split.
+// Use TruncateWith with UnitBytes and KeepGraphemes to avoid splitting grapheme clusters too.
+// if maxBytes is less than 4 (3 bytes for ellipsis + 1 byte from string), returns empty string
+func TruncateBytes(s string, maxBytes int) string {
+	return TruncateWith(s, TruncateOptions{MaxLen: maxBytes, Unit: UnitBytes})
+}
+
+func truncateWords(s string, maxWords int, pos TruncatePosition, ellipsis string) string {
+	if maxWords <= 0 {
+		return ""
+	}
+
+	words := strings.Fields(s)
+	if len(words) <= maxWords {
+		return s
+	}
+
+	switch pos {
+	case TruncateStart:
+		return ellipsis + strings.Join(words[len(words)-maxWords:], " ")
+	case TruncateMiddle:
+		head := maxWords - maxWords/2
+		return strings.Join(words[:head], " ") + ellipsis + strings.Join(words[len(words)-maxWords/2:], " ")
+	default:
+		return strings.Join(words[:maxWords], " ") + ellipsis
+	}
+}
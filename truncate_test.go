@@ -0,0 +1,155 @@
+package stringutils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateWith(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  TruncateOptions
+		want  string
+	}{
+		{"defaults same as Truncate", "hello world", TruncateOptions{MaxLen: 6}, "hel..."},
+		{"no truncation needed", "hello", TruncateOptions{MaxLen: 5, Position: TruncateMiddle}, "hello"},
+		{"custom ellipsis", "hello world", TruncateOptions{MaxLen: 6, Ellipsis: "…"}, "hello…"},
+		{"custom long ellipsis", "hello beautiful world", TruncateOptions{MaxLen: 15, Ellipsis: " [more]"}, "hello be [more]"},
+		{"no ellipsis", "hello world", TruncateOptions{MaxLen: 5, NoEllipsis: true}, "hello"},
+		{"no ellipsis zero len", "hello world", TruncateOptions{MaxLen: 0, NoEllipsis: true}, ""},
+		{"custom ellipsis too long", "hello world", TruncateOptions{MaxLen: 7, Ellipsis: " [more]"}, ""},
+		{"custom ellipsis fits one rune", "hello world", TruncateOptions{MaxLen: 2, Ellipsis: "…"}, "h…"},
+		{"start", "hello world", TruncateOptions{MaxLen: 8, Position: TruncateStart}, "...world"},
+		{"start custom ellipsis", "/usr/local/bin/app", TruncateOptions{MaxLen: 9, Position: TruncateStart, Ellipsis: "…"}, "…/bin/app"},
+		{"middle", "hello world", TruncateOptions{MaxLen: 8, Position: TruncateMiddle}, "hel...ld"},
+		{"middle path", "/usr/local/share/app/config.yml", TruncateOptions{MaxLen: 16, Position: TruncateMiddle, Ellipsis: "…"},
+			"/usr/loc…fig.yml"},
+		{"middle minimal", "abcdef", TruncateOptions{MaxLen: 4, Position: TruncateMiddle}, "a..."},
+		{"middle unicode id", "привет-мир-123", TruncateOptions{MaxLen: 9, Position: TruncateMiddle}, "при...123"},
+		{"word boundary end", "hello beautiful world", TruncateOptions{MaxLen: 14, WordBoundary: true}, "hello..."},
+		{"word boundary exact word end", "hello beautiful world", TruncateOptions{MaxLen: 18, WordBoundary: true},
+			"hello beautiful..."},
+		{"word boundary long word", "supercalifragilistic word", TruncateOptions{MaxLen: 8, WordBoundary: true}, "super..."},
+		{"word boundary leading spaces", "   abcdefghijklmnop", TruncateOptions{MaxLen: 8, WordBoundary: true}, "   ab..."},
+		{"word boundary trailing spaces start", "abcdefghijklmnop   ", TruncateOptions{MaxLen: 8, Position: TruncateStart,
+			WordBoundary: true}, "...op   "},
+		{"word boundary leading spaces no ellipsis", "  abcdefghij", TruncateOptions{MaxLen: 5, WordBoundary: true,
+			NoEllipsis: true}, "  abc"},
+		{"word boundary start", "hello beautiful world", TruncateOptions{MaxLen: 14, Position: TruncateStart, WordBoundary: true},
+			"...world"},
+		{"word boundary middle", "one two three four five", TruncateOptions{MaxLen: 15, Position: TruncateMiddle, WordBoundary: true},
+			"one...five"},
+		{"graphemes unit", "👍🏽👍🏽👍🏽👍🏽👍🏽", TruncateOptions{MaxLen: 3, Unit: UnitGraphemes, Ellipsis: "…"}, "👍🏽👍🏽…"},
+		{"width unit", "中文字符串", TruncateOptions{MaxLen: 5, Unit: UnitWidth, Ellipsis: "…"}, "中文…"},
+		{"width unit start", "中文字符串", TruncateOptions{MaxLen: 5, Unit: UnitWidth, Position: TruncateStart, Ellipsis: "…"}, "…符串"},
+		{"words unit", "hello beautiful world", TruncateOptions{MaxLen: 2, Unit: UnitWords}, "hello beautiful..."},
+		{"words unit custom ellipsis", "hello beautiful world", TruncateOptions{MaxLen: 1, Unit: UnitWords, Ellipsis: " [more]"},
+			"hello [more]"},
+		{"words unit start", "one two three four", TruncateOptions{MaxLen: 2, Unit: UnitWords, Position: TruncateStart}, "...three four"},
+		{"words unit middle", "one two three four five", TruncateOptions{MaxLen: 3, Unit: UnitWords, Position: TruncateMiddle},
+			"one two...five"},
+		{"words unit negative", "one two", TruncateOptions{MaxLen: -1, Unit: UnitWords}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TruncateWith(tt.input, tt.opts))
+		})
+	}
+}
+
+func TestTruncateWith_LimitRespected(t *testing.T) {
+	inputs := []string{"hello beautiful world", "привет мир, как дела", "a b c d e f g h", "👍🏽 x 👍🏽 y 👍🏽"}
+	for _, s := range inputs {
+		for maxLen := 0; maxLen < 25; maxLen++ {
+			for _, pos := range []TruncatePosition{TruncateEnd, TruncateStart, TruncateMiddle} {
+				for _, wb := range []bool{false, true} {
+					opts := TruncateOptions{MaxLen: maxLen, Position: pos, WordBoundary: wb, Ellipsis: "…"}
+					got := TruncateWith(s, opts)
+					assert.LessOrEqual(t, measure(got, UnitRunes), maxLen, "%q %+v", s, opts)
+					opts.Unit = UnitGraphemes
+					got = TruncateWith(s, opts)
+					assert.LessOrEqual(t, measure(got, UnitGraphemes), maxLen, "%q %+v", s, opts)
+				}
+			}
+		}
+	}
+}
+
+func TestTruncateBytes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxBytes int
+		want     string
+	}{
+		{"no truncation needed", "hello", 10, "hello"},
+		{"exactly at limit", "hello", 5, "hello"},
+		{"truncation needed", "hello world", 8, "hello..."},
+		{"maxBytes too small", "hello", 3, ""},
+		{"negative maxBytes", "hello", -1, ""},
+		{"cyrillic not split", "привет", 8, "пр..."},
+		{"cyrillic fits exactly", "привет", 12, "привет"},
+		{"multi-byte rune doesn't fit", "中文字", 5, ""},
+		{"multi-byte rune fits", "中文字", 6, "中..."},
+		{"emoji", "👋🌍✨", 10, "👋..."},
+		{"combining accent may be split", "e\u0301e\u0301", 5, "e..."},
+		{"invalid utf8 bytes", "\xff\xfe\xfdabc", 5, "\xff\xfe..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateBytes(tt.input, tt.maxBytes)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), max(tt.maxBytes, 0))
+		})
+	}
+}
+
+func TestTruncateWith_Bytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  TruncateOptions
+		want  string
+	}{
+		{"keep graphemes", "e\u0301e\u0301", TruncateOptions{MaxLen: 5, Unit: UnitBytes, KeepGraphemes: true}, ""},
+		{"keep graphemes fits one", "e\u0301e\u0301e\u0301", TruncateOptions{MaxLen: 6, Unit: UnitBytes, KeepGraphemes: true}, "e\u0301..."},
+		{"keep graphemes flag", "🇺🇸🇬🇧", TruncateOptions{MaxLen: 10, Unit: UnitBytes, KeepGraphemes: true, Ellipsis: "…"}, ""},
+		{"split flag without keep graphemes", "🇺🇸🇬🇧", TruncateOptions{MaxLen: 10, Unit: UnitBytes, Ellipsis: "…"}, "🇺…"},
+		{"keep graphemes zwj", "👨‍👩‍👧 hi there", TruncateOptions{MaxLen: 21, Unit: UnitBytes, KeepGraphemes: true}, "👨‍👩‍👧..."},
+		{"custom ellipsis bytes", "hello world", TruncateOptions{MaxLen: 8, Unit: UnitBytes, Ellipsis: "…"}, "hello…"},
+		{"start", "hello мир", TruncateOptions{MaxLen: 9, Unit: UnitBytes, Position: TruncateStart}, "...мир"},
+		{"no ellipsis", "привет", TruncateOptions{MaxLen: 5, Unit: UnitBytes, NoEllipsis: true}, "пр"},
+		{"runes keep graphemes", "e\u0301e\u0301e\u0301e\u0301", TruncateOptions{MaxLen: 6, KeepGraphemes: true}, "e\u0301..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateWith(tt.input, tt.opts)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), max(tt.opts.MaxLen, len(tt.input)))
+		})
+	}
+}
+
+func BenchmarkTruncateWith(b *testing.B) {
+	long := strings.Repeat("hello beautiful world ", 5000)
+	for _, opts := range []TruncateOptions{
+		{MaxLen: 100},
+		{MaxLen: 100, Unit: UnitGraphemes},
+		{MaxLen: 100, Unit: UnitWidth, WordBoundary: true},
+		{MaxLen: len(long)},
+	} {
+		b.Run(fmt.Sprintf("%d/%d", opts.Unit, opts.MaxLen), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = TruncateWith(long, opts)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package stringutils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// UniqueWords generates n distinct pronounceable random words with length between minLen and maxLen, using crypto/rand.
+// See Generator.UniqueWords for details.
+func UniqueWords(n, minLen, maxLen int) ([]string, error) {
+	return NewGenerator(rand.Reader).UniqueWords(n, minLen, maxLen)
+}
+
+// UniqueWords generates n distinct pronounceable random words with length between minLen and maxLen, made the same way
+// as by RandomWord. Returns error if n is more than the number of possible words of these lengths.
+// If n is a noticeable part of all possible words, they are all listed and n of them are picked at random, so the
+// time doesn't grow as the space fills up; in this case every word is equally likely, regardless of its length.
+// Otherwise words are generated with RandomWord and duplicates are dropped.
+// The blocklist and the checker of the generator are applied, and may leave fewer than n possible words.
+func (g *Generator) UniqueWords(n, minLen, maxLen int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("negative number of words %d", n)
+	}
+	if minLen < 2 {
+		minLen = 2
+	}
+	if maxLen < minLen {
+		maxLen = minLen
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	capacity := randomWordCapacity(minLen, maxLen)
+	if capacity.Cmp(big.NewInt(int64(n))) < 0 {
+		return nil, fmt.Errorf("can't make %d unique words of %d-%d letters, only %s are possible", n, minLen, maxLen, capacity)
+	}
+
+	// list all words if there are not many more of them than requested
+	const denseFactor = 4
+	if capacity.Cmp(new(big.Int).Mul(big.NewInt(int64(n)), big.NewInt(denseFactor))) <= 0 {
+		return g.pickUniqueWords(n, minLen, maxLen)
+	}
+
+	// the space is at least denseFactor times bigger than n, so most of the draws are new words
+	maxAttempts := 16*n*(maxLen-minLen+1) + defaultMaxRetries
+	seen := make(map[string]struct{}, n)
+	result := make([]string, 0, n)
+	for attempt := 0; attempt < maxAttempts && len(result) < n; attempt++ {
+		w, err := g.RandomWordE(minLen, maxLen)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		result = append(result, w)
+	}
+	if len(result) < n {
+		return nil, fmt.Errorf("made only %d unique words of %d in %d attempts", len(result), n, maxAttempts)
+	}
+	return result, nil
+}
+
+// pickUniqueWords lists all allowed words of the given lengths and picks n of them with partial Fisher-Yates shuffle
+func (g *Generator) pickUniqueWords(n, minLen, maxLen int) ([]string, error) {
+	g.mu.Lock()
+	blocklist, checker := g.blocklist, g.checker
+	g.mu.Unlock()
+
+	var words []string
+	for length := minLen; length <= maxLen; length++ {
+		for _, startWithVowel := range []bool{true, false} {
+			listWords(length, startWithVowel, func(w string) {
+				if (blocklist == nil || !blocklist.Match(w)) && (checker == nil || checker(w)) {
+					words = append(words, w)
+				}
+			})
+		}
+	}
+	if len(words) < n {
+		return nil, fmt.Errorf("can't make %d unique words of %d-%d letters, only %d are allowed", n, minLen, maxLen, len(words))
+	}
+
+	for i := 0; i < n; i++ {
+		j, err := g.intn(len(words) - i)
+		if err != nil {
+			return nil, err
+		}
+		words[i], words[i+j] = words[i+j], words[i]
+	}
+	return words[:n], nil
+}
+
+// listWords calls fn for every word RandomWord can make with the given length and first letter kind
+func listWords(length int, startWithVowel bool, fn func(w string)) {
+	const vowels, consonants = "aeiou", "bcdfghjklmnpqrstvwxyz"
+	letters := make([]string, length)
+	for i := range letters {
+		letters[i] = consonants
+		if (i%2 == 0) == startWithVowel {
+			letters[i] = vowels
+		}
+	}
+
+	idx := make([]int, length)
+	buf := make([]byte, length)
+	for {
+		for i, k := range idx {
+			buf[i] = letters[i][k]
+		}
+		fn(string(buf))
+
+		// increment mixed-radix counter, the last letter changes first
+		i := length - 1
+		for ; i >= 0; i-- {
+			if idx[i]++; idx[i] < len(letters[i]) {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// randomWordCapacity returns number of distinct words RandomWord(minLen, maxLen) can make, minLen >= 2
+func randomWordCapacity(minLen, maxLen int) *big.Int {
+	total := new(big.Int)
+	v, c := big.NewInt(5), big.NewInt(21)
+	for n := minLen; n <= maxLen; n++ {
+		long, short := big.NewInt(int64((n+1)/2)), big.NewInt(int64(n/2))
+		// words starting with a vowel have (n+1)/2 vowels, words starting with a consonant have (n+1)/2 consonants
+		vowelFirst := new(big.Int).Mul(new(big.Int).Exp(v, long, nil), new(big.Int).Exp(c, short, nil))
+		consonantFirst := new(big.Int).Mul(new(big.Int).Exp(c, long, nil), new(big.Int).Exp(v, short, nil))
+		total.Add(total, vowelFirst).Add(total, consonantFirst)
+	}
+	return total
+}
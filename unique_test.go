@@ -0,0 +1,85 @@
+package stringutils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_UniqueWords(t *testing.T) {
+	tests := []struct {
+		name              string
+		n, minLen, maxLen int
+	}{
+		{"sparse", 10000, 4, 8},
+		{"dense all two-letter words", 210, 2, 2},
+		{"dense most of the space", 2500, 2, 3},
+		{"small", 5, 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, err := NewSeededGenerator(1).UniqueWords(tt.n, tt.minLen, tt.maxLen)
+			require.NoError(t, err)
+			require.Len(t, words, tt.n)
+			assert.Len(t, DeDup(words), tt.n, "all words are unique")
+			for _, w := range words {
+				require.GreaterOrEqual(t, len(w), tt.minLen)
+				require.LessOrEqual(t, len(w), tt.maxLen)
+			}
+		})
+	}
+}
+
+func TestGenerator_UniqueWordsErrors(t *testing.T) {
+	_, err := NewSeededGenerator(1).UniqueWords(211, 2, 2)
+	require.EqualError(t, err, "can't make 211 unique words of 2-2 letters, only 210 are possible")
+
+	_, err = NewSeededGenerator(1).UniqueWords(-1, 4, 8)
+	require.Error(t, err)
+
+	words, err := NewSeededGenerator(1).UniqueWords(0, 4, 8)
+	require.NoError(t, err)
+	assert.Empty(t, words)
+
+	_, err = NewGenerator(failingReader{err: errEntropy}).UniqueWords(10, 4, 8)
+	require.ErrorIs(t, err, errEntropy)
+	_, err = NewGenerator(failingReader{err: errEntropy}).UniqueWords(10, 2, 2)
+	require.ErrorIs(t, err, errEntropy)
+
+	// blocklist leaves fewer words than requested
+	gen := NewSeededGenerator(1)
+	gen.SetBlocklist([]string{"a"})
+	_, err = gen.UniqueWords(200, 2, 2)
+	require.Error(t, err)
+	words, err = gen.UniqueWords(100, 2, 2)
+	require.NoError(t, err)
+	for _, w := range words {
+		assert.NotContains(t, w, "a")
+	}
+}
+
+func TestGenerator_UniqueWordsSeeded(t *testing.T) {
+	w1, err := NewSeededGenerator(7).UniqueWords(100, 3, 6)
+	require.NoError(t, err)
+	w2, err := NewSeededGenerator(7).UniqueWords(100, 3, 6)
+	require.NoError(t, err)
+	assert.Equal(t, w1, w2)
+
+	words, err := UniqueWords(50, 4, 8)
+	require.NoError(t, err)
+	assert.Len(t, DeDup(words), 50)
+}
+
+func TestRandomWordCapacity(t *testing.T) {
+	assert.Equal(t, "210", randomWordCapacity(2, 2).String())
+	assert.Equal(t, "2940", randomWordCapacity(2, 3).String())
+
+	count := 0
+	for _, v := range []bool{true, false} {
+		listWords(4, v, func(string) { count++ })
+	}
+	assert.Equal(t, randomWordCapacity(4, 4).String(), strconv.Itoa(count))
+}
@@ -0,0 +1,82 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WhitespaceOptions defines parameters of NormalizeWhitespaceWith and IsBlankWith.
+// Zero value makes them work as NormalizeWhitespace and IsBlank.
+type WhitespaceOptions struct {
+	Invisible     InvisibleCategory // invisible characters treated as whitespace, e.g. InvisibleAll
+	PreserveLines bool              // keep line breaks and indentation, collapse whitespace within lines only
+	MaxBlankLines int               // limit of consecutive blank lines with PreserveLines, 1 if 0, no limit if negative
+	NoBlankLines  bool              // remove all blank lines with PreserveLines, MaxBlankLines is ignored
+}
+
+// NormalizeWhitespaceWith replaces multiple whitespace characters with single space and trims,
+// treating characters of opts.Invisible categories as whitespace.
+// With opts.PreserveLines it works per line instead: CRLF and CR line endings become LF, leading indentation is
+// kept, other whitespace runs within lines become single space and trailing whitespace is trimmed. Runs of blank lines
+// are limited to opts.MaxBlankLines, or removed with opts.NoBlankLines, and blank lines at the beginning and the end
+// are removed.
+func NormalizeWhitespaceWith(s string, opts WhitespaceOptions) string {
+	if opts.PreserveLines {
+		return normalizeLines(s, opts)
+	}
+	fields := strings.FieldsFunc(s, opts.isSpace)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Join(fields, " ")
+}
+
+// IsBlankWith returns true if string is empty or contains only whitespace,
+// treating characters of opts.Invisible categories as whitespace. Other options are not used.
+func IsBlankWith(s string, opts WhitespaceOptions) bool {
+	for _, r := range s {
+		if !opts.isSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeLines normalizes whitespace of every line of s, see NormalizeWhitespaceWith
+func normalizeLines(s string, opts WhitespaceOptions) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	maxBlank := opts.MaxBlankLines
+	if maxBlank == 0 {
+		maxBlank = 1
+	}
+	if opts.NoBlankLines {
+		maxBlank = 0
+	}
+
+	var result []string
+	blanks := 0 // blank lines since the last non-blank one
+	for _, line := range strings.Split(s, "\n") {
+		text := strings.TrimLeftFunc(line, unicode.IsSpace)
+		fields := strings.FieldsFunc(text, opts.isSpace)
+		if len(fields) == 0 {
+			blanks++
+			continue
+		}
+		if len(result) > 0 {
+			if maxBlank >= 0 {
+				blanks = min(blanks, maxBlank)
+			}
+			for ; blanks > 0; blanks-- {
+				result = append(result, "")
+			}
+		}
+		blanks = 0
+		result = append(result, line[:len(line)-len(text)]+strings.Join(fields, " "))
+	}
+	return strings.Join(result, "\n")
+}
+
+func (o WhitespaceOptions) isSpace(r rune) bool {
+	return unicode.IsSpace(r) || o.Invisible&InvisibleCategoryOf(r) != 0
+}
@@ -0,0 +1,96 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeWhitespaceWith(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		opts WhitespaceOptions
+		want string
+	}{
+		{"zero options", "  a \t\n b  ", WhitespaceOptions{}, "a b"},
+		{"zero options keeps invisible", "a \u200b b", WhitespaceOptions{}, "a \u200b b"},
+		{"all invisible", "\u200ba \u200b b\u3164", WhitespaceOptions{Invisible: InvisibleAll}, "a b"},
+		{"invisible separates words", "a\u200bb", WhitespaceOptions{Invisible: InvisibleZeroWidth}, "a b"},
+		{"only selected category", "a\u200b\u3164b", WhitespaceOptions{Invisible: InvisibleFiller}, "a\u200b b"},
+		{"only invisible", "\u200b\ufeff", WhitespaceOptions{Invisible: InvisibleAll}, ""},
+		{"empty", "", WhitespaceOptions{Invisible: InvisibleAll}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeWhitespaceWith(tt.s, tt.opts))
+		})
+	}
+
+	for _, s := range []string{"", " ", "a  b", "\t a\n\nb \r\n", "\u00a0x\u3000y"} {
+		assert.Equal(t, NormalizeWhitespace(s), NormalizeWhitespaceWith(s, WhitespaceOptions{}), "%q", s)
+	}
+}
+
+func TestIsBlankWith(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		opts WhitespaceOptions
+		want bool
+	}{
+		{"empty", "", WhitespaceOptions{}, true},
+		{"spaces", " \t\n", WhitespaceOptions{}, true},
+		{"zero-width space not blank by default", "\u200b", WhitespaceOptions{}, false},
+		{"zero-width space", "\u200b \u200b", WhitespaceOptions{Invisible: InvisibleZeroWidth}, true},
+		{"word joiner and bom", "\u2060\ufeff", WhitespaceOptions{Invisible: InvisibleAll}, true},
+		{"hangul fillers", "\u3164\u115f\uffa0", WhitespaceOptions{Invisible: InvisibleAll}, true},
+		{"filler not selected", "\u3164", WhitespaceOptions{Invisible: InvisibleZeroWidth}, false},
+		{"text with invisible", "\u200bhi\u200b", WhitespaceOptions{Invisible: InvisibleAll}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsBlankWith(tt.s, tt.opts))
+		})
+	}
+
+	for _, s := range []string{"", " ", "a", "\u00a0\u3000", "\u200b"} {
+		assert.Equal(t, IsBlank(s), IsBlankWith(s, WhitespaceOptions{}), "%q", s)
+	}
+}
+
+func TestNormalizeWhitespaceWith_PreserveLines(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		opts WhitespaceOptions
+		want string
+	}{
+		{"single line", "  a   b\t\tc  ", WhitespaceOptions{PreserveLines: true}, "  a b c"},
+		{"lines kept", "one  two\nthree\t four", WhitespaceOptions{PreserveLines: true}, "one two\nthree four"},
+		{"trailing whitespace trimmed", "a \t\nb  \n", WhitespaceOptions{PreserveLines: true}, "a\nb"},
+		{"indentation kept", "list:\n  - a  b\n\t- c", WhitespaceOptions{PreserveLines: true}, "list:\n  - a b\n\t- c"},
+		{"crlf", "a\r\nb\r\n\r\nc", WhitespaceOptions{PreserveLines: true}, "a\nb\n\nc"},
+		{"cr", "a\rb\r\rc", WhitespaceOptions{PreserveLines: true}, "a\nb\n\nc"},
+		{"paragraphs", "p1 line1\np1 line2\n\n\n\np2", WhitespaceOptions{PreserveLines: true}, "p1 line1\np1 line2\n\np2"},
+		{"whitespace-only lines are blank", "a\n  \t \n \nb", WhitespaceOptions{PreserveLines: true}, "a\n\nb"},
+		{"max blank lines", "a\n\n\n\n\nb", WhitespaceOptions{PreserveLines: true, MaxBlankLines: 2}, "a\n\n\nb"},
+		{"max blank lines not reached", "a\n\nb", WhitespaceOptions{PreserveLines: true, MaxBlankLines: 3}, "a\n\nb"},
+		{"no blank lines", "a\n\n\nb\n\nc", WhitespaceOptions{PreserveLines: true, NoBlankLines: true}, "a\nb\nc"},
+		{"no blank lines wins over max", "a\n\nb", WhitespaceOptions{PreserveLines: true, NoBlankLines: true, MaxBlankLines: 3},
+			"a\nb"},
+		{"no blank lines with crlf", "a\r\n \r\nb", WhitespaceOptions{PreserveLines: true, NoBlankLines: true}, "a\nb"},
+		{"no blank line limit", "a\n\n\n\nb", WhitespaceOptions{PreserveLines: true, MaxBlankLines: -1}, "a\n\n\n\nb"},
+		{"leading and trailing blank lines", "\n\n  \na\n\n\n", WhitespaceOptions{PreserveLines: true}, "a"},
+		{"only whitespace", " \n\r\n\t", WhitespaceOptions{PreserveLines: true}, ""},
+		{"empty", "", WhitespaceOptions{PreserveLines: true}, ""},
+		{"invisible", "a\u200b\u200bb\n\u200b\n\u3164\nc\u200b", WhitespaceOptions{PreserveLines: true, Invisible: InvisibleAll},
+			"a b\n\nc"},
+		{"max blank lines ignored without preserve lines", "a\n\n\nb", WhitespaceOptions{MaxBlankLines: 2}, "a b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeWhitespaceWith(tt.s, tt.opts))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package stringutils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// RuneWidth returns number of terminal cells needed to display r, based on East Asian Width (UAX #11).
+// Wide and fullwidth characters, like CJK ideographs and most emoji, take two cells.
+// Control, zero-width, combining and format characters take no cells. Ambiguous characters are treated as narrow.
+func RuneWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7f:
+		return 0
+	case r < 0x7f:
+		return 1 // printable ASCII
+	case r >= 0x1160 && r <= 0x11FF, r >= 0xD7B0 && r <= 0xD7FF: // hangul medial vowels and final consonants
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// StringWidth returns number of terminal cells needed to display s.
+// Width is computed per grapheme cluster, so an emoji sequence or a letter with combining marks is
+// counted once. Emoji with variation selector 16 (emoji presentation) and flags take two cells.
+func StringWidth(s string) int {
+	w := 0
+	for s != "" {
+		n := nextGrapheme(s)
+		w += graphemeWidth(s[:n])
+		s = s[n:]
+	}
+	return w
+}
+
+// TruncateWidth cuts string to the given display width (in terminal cells) and adds ellipsis if it was truncated.
+// Grapheme clusters are never split, so the result can be narrower than maxWidth if a wide character doesn't fit.
+// if maxWidth is less than 4 (3 cells for ellipsis + 1 cell from string), returns empty string
+func TruncateWidth(s string, maxWidth int) string {
+	return TruncateWith(s, TruncateOptions{MaxLen: maxWidth, Unit: UnitWidth})
+}
+
+// PadRight appends spaces to s to make it the given display width (in terminal cells).
+// Returns s unchanged if it is already as wide or wider.
+func PadRight(s string, w int) string {
+	pad := w - StringWidth(s)
+	if pad <= 0 {
+		return s
+	}
+	return s + strings.Repeat(" ", pad)
+}
+
+// PadLeft prepends spaces to s to make it the given display width (in terminal cells).
+// Returns s unchanged if it is already as wide or wider.
+func PadLeft(s string, w int) string {
+	pad := w - StringWidth(s)
+	if pad <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", pad) + s
+}
+
+// Center surrounds s with spaces to make it the given display width (in terminal cells).
+// If padding can't be split evenly, the extra space goes to the right.
+// Returns s unchanged if it is already as wide or wider.
+func Center(s string, w int) string {
+	pad := w - StringWidth(s)
+	if pad <= 0 {
+		return s
+	}
+	left := pad / 2
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", pad-left)
+}
+
+// graphemeWidth returns display width of a single grapheme cluster
+func graphemeWidth(cluster string) int {
+	r, size := utf8.DecodeRuneInString(cluster)
+	if size == len(cluster) {
+		return RuneWidth(r)
+	}
+	if graphemePropOf(r) == gbRegionalIndicator || strings.ContainsRune(cluster, 0xFE0F) {
+		return 2 // flag or emoji presentation sequence
+	}
+	w := 0
+	for _, r := range cluster {
+		w = max(w, RuneWidth(r))
+	}
+	return w
+}
@@ -0,0 +1,120 @@
+package stringutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want int
+	}{
+		{"ascii letter", 'a', 1},
+		{"space", ' ', 1},
+		{"control", '\t', 0},
+		{"null", 0, 0},
+		{"cyrillic", 'ж', 1},
+		{"cjk ideograph", '中', 2},
+		{"hiragana", 'あ', 2},
+		{"hangul syllable", '한', 2},
+		{"fullwidth latin", 'Ａ', 2},
+		{"halfwidth katakana", 'ｱ', 1},
+		{"emoji", '🌍', 2},
+		{"combining accent", 0x0301, 0},
+		{"zero width space", 0x200B, 0},
+		{"zero width joiner", 0x200D, 0},
+		{"bom", 0xFEFF, 0},
+		{"variation selector", 0xFE0F, 0},
+		{"hangul medial vowel", 0x1161, 0},
+		{"ambiguous is narrow", '±', 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RuneWidth(tt.r))
+		})
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"empty", "", 0},
+		{"ascii", "hello", 5},
+		{"cjk", "中文字", 6},
+		{"mixed", "ab中c", 5},
+		{"combining", "e\u0301e\u0301", 2},
+		{"emoji", "👋🌍", 4},
+		{"zwj family", "👨‍👩‍👧", 2},
+		{"skin tone", "👍🏽", 2},
+		{"flag", "🇺🇸", 2},
+		{"emoji presentation selector", "❤️", 2},
+		{"text heart", "❤", 1},
+		{"hangul jamo cluster", "각", 2},
+		{"zero width chars", "a\u200bb\ufeff", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StringWidth(tt.s))
+		})
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxWidth int
+		want     string
+	}{
+		{"no truncation needed", "hello", 10, "hello"},
+		{"truncation needed", "hello world", 6, "hel..."},
+		{"maxWidth too small", "hello", 3, ""},
+		{"exactly at limit", "中文字", 6, "中文字"},
+		{"cjk truncated", "中文字符串", 7, "中文..."},
+		{"wide char doesn't fit", "中文字符串", 6, "中..."},
+		{"combining accents not split", "e\u0301e\u0301e\u0301e\u0301e\u0301e\u0301", 5, "e\u0301e\u0301..."},
+		{"emoji", "👋🌍✨🎉", 7, "👋🌍..."},
+		{"zwj sequence not split", "👨‍👩‍👧👨‍👩‍👧👨‍👩‍👧", 5, "👨‍👩‍👧..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateWidth(tt.input, tt.maxWidth)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, StringWidth(got), max(tt.maxWidth, 0))
+		})
+	}
+}
+
+func TestPadding(t *testing.T) {
+	tests := []struct {
+		name                string
+		s                   string
+		width               int
+		left, right, center string
+	}{
+		{"ascii", "ab", 5, "   ab", "ab   ", " ab  "},
+		{"cjk", "中文", 6, "  中文", "中文  ", " 中文 "},
+		{"combining", "e\u0301a", 4, "  e\u0301a", "e\u0301a  ", " e\u0301a "},
+		{"emoji", "👋", 3, " 👋", "👋 ", "👋 "},
+		{"already wide", "中文", 3, "中文", "中文", "中文"},
+		{"exact width", "abc", 3, "abc", "abc", "abc"},
+		{"empty", "", 2, "  ", "  ", "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.left, PadLeft(tt.s, tt.width))
+			assert.Equal(t, tt.right, PadRight(tt.s, tt.width))
+			assert.Equal(t, tt.center, Center(tt.s, tt.width))
+		})
+	}
+}
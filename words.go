@@ -0,0 +1,293 @@
+package stringutils
+
+import "unicode"
+
+// WordSegments splits s at word boundaries as defined by Unicode Standard Annex #29.
+// Every part of s is kept, so words, whitespace runs and punctuation become separate segments and
+// joining the segments gives back s. Ideographs and hiragana have no boundaries between words without
+// a dictionary, so each of them becomes a separate segment. Words with inner apostrophes or dots, like
+// "can't" and "3.14", stay whole, while hyphenated words are split.
+func WordSegments(s string) []string {
+	if s == "" {
+		return nil
+	}
+	bounds := wordBoundaries(s)
+	result := make([]string, 0, len(bounds))
+	start := 0
+	for _, end := range bounds {
+		result = append(result, s[start:end])
+		start = end
+	}
+	return result
+}
+
+// UnicodeWords returns words of s, i.e. segments produced by WordSegments which contain letters or digits
+func UnicodeWords(s string) []string {
+	var result []string
+	for _, seg := range WordSegments(s) {
+		if isWordSegment(seg) {
+			result = append(result, seg)
+		}
+	}
+	return result
+}
+
+// TruncateWordsUnicode cuts string to the given number of words and adds ellipsis if it was truncated.
+// Unlike TruncateWords, words are found by Unicode word boundaries (UAX #29), so CJK text without spaces
+// is counted per ideograph and punctuation is not part of words. Whitespace and punctuation between kept
+// words are preserved as is, including newlines.
+// returns empty string if maxWords is less than 1
+func TruncateWordsUnicode(s string, maxWords int) string {
+	return TruncateWith(s, TruncateOptions{MaxLen: maxWords, Unit: UnitUnicodeWords})
+}
+
+// wordSpan is a byte range of a word in a string
+type wordSpan struct{ start, end int }
+
+// wordSpans returns byte ranges of words in s, as returned by UnicodeWords
+func wordSpans(s string) []wordSpan {
+	var result []wordSpan
+	start := 0
+	for _, end := range wordBoundaries(s) {
+		if isWordSegment(s[start:end]) {
+			result = append(result, wordSpan{start: start, end: end})
+		}
+		start = end
+	}
+	return result
+}
+
+func truncateUnicodeWords(s string, maxWords int, pos TruncatePosition, ellipsis string) string {
+	if maxWords <= 0 {
+		return ""
+	}
+
+	words := wordSpans(s)
+	if len(words) <= maxWords {
+		return s
+	}
+
+	switch pos {
+	case TruncateStart:
+		return ellipsis + s[words[len(words)-maxWords].start:]
+	case TruncateMiddle:
+		head := maxWords - maxWords/2
+		tail := len(s)
+		if maxWords/2 > 0 {
+			tail = words[len(words)-maxWords/2].start
+		}
+		return s[:words[head-1].end] + ellipsis + s[tail:]
+	default:
+		return s[:words[maxWords-1].end] + ellipsis
+	}
+}
+
+func isWordSegment(seg string) bool {
+	for _, r := range seg {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// wordProp is a value of Word_Break property, with Extended_Pictographic added
+type wordProp int
+
+const (
+	wbOther wordProp = iota
+	wbCR
+	wbLF
+	wbNewline
+	wbExtend
+	wbZWJ
+	wbRegionalIndicator
+	wbFormat
+	wbKatakana
+	wbHebrewLetter
+	wbALetter
+	wbSingleQuote
+	wbDoubleQuote
+	wbMidNumLet
+	wbMidLetter
+	wbMidNum
+	wbNumeric
+	wbExtendNumLet
+	wbWSegSpace
+	wbExtPict
+)
+
+// wordBoundaries returns byte offsets of the ends of all word segments in s, applying UAX #29 rules WB3-WB999
+func wordBoundaries(s string) []int {
+	props := make([]wordProp, 0, len(s))
+	offsets := make([]int, 0, len(s)+1)
+	for i, r := range s {
+		props = append(props, wordPropOf(r))
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(s))
+
+	ignorable := func(p wordProp) bool { return p == wbExtend || p == wbFormat || p == wbZWJ }
+	// prevIdx returns index of the closest rune at or before i which is not ignored by WB4, or -1
+	prevIdx := func(i int) int {
+		for i >= 0 && ignorable(props[i]) {
+			i--
+		}
+		return i
+	}
+	propAt := func(i int) wordProp {
+		if i < 0 || i >= len(props) {
+			return wbOther
+		}
+		return props[i]
+	}
+	ahLetter := func(p wordProp) bool { return p == wbALetter || p == wbHebrewLetter }
+	midNumLetQ := func(p wordProp) bool { return p == wbMidNumLet || p == wbSingleQuote }
+
+	isBreak := func(i int) bool {
+		p, c := props[i-1], props[i]
+		switch {
+		case p == wbCR && c == wbLF: // WB3
+			return false
+		case p == wbCR || p == wbLF || p == wbNewline: // WB3a
+			return true
+		case c == wbCR || c == wbLF || c == wbNewline: // WB3b
+			return true
+		case p == wbZWJ && c == wbExtPict: // WB3c
+			return false
+		case p == wbWSegSpace && c == wbWSegSpace: // WB3d
+			return false
+		case ignorable(c): // WB4
+			return false
+		}
+
+		pi := prevIdx(i - 1)
+		if pi < 0 {
+			return true
+		}
+		p = props[pi]
+		pp := propAt(prevIdx(pi - 1))
+		ni := i + 1
+		for ni < len(props) && ignorable(props[ni]) {
+			ni++
+		}
+		n := propAt(ni)
+
+		switch {
+		case ahLetter(p) && ahLetter(c): // WB5
+			return false
+		case ahLetter(p) && (c == wbMidLetter || midNumLetQ(c)) && ahLetter(n): // WB6
+			return false
+		case ahLetter(pp) && (p == wbMidLetter || midNumLetQ(p)) && ahLetter(c): // WB7
+			return false
+		case p == wbHebrewLetter && c == wbSingleQuote: // WB7a
+			return false
+		case p == wbHebrewLetter && c == wbDoubleQuote && n == wbHebrewLetter: // WB7b
+			return false
+		case pp == wbHebrewLetter && p == wbDoubleQuote && c == wbHebrewLetter: // WB7c
+			return false
+		case p == wbNumeric && c == wbNumeric, ahLetter(p) && c == wbNumeric, p == wbNumeric && ahLetter(c): // WB8-WB10
+			return false
+		case pp == wbNumeric && (p == wbMidNum || midNumLetQ(p)) && c == wbNumeric: // WB11
+			return false
+		case p == wbNumeric && (c == wbMidNum || midNumLetQ(c)) && n == wbNumeric: // WB12
+			return false
+		case p == wbKatakana && c == wbKatakana: // WB13
+			return false
+		case (ahLetter(p) || p == wbNumeric || p == wbKatakana || p == wbExtendNumLet) && c == wbExtendNumLet: // WB13a
+			return false
+		case p == wbExtendNumLet && (ahLetter(c) || c == wbNumeric || c == wbKatakana): // WB13b
+			return false
+		case p == wbRegionalIndicator && c == wbRegionalIndicator: // WB15, WB16
+			count := 0
+			for j := pi; j >= 0 && propAt(j) == wbRegionalIndicator; j = prevIdx(j - 1) {
+				count++
+			}
+			return count%2 == 0
+		}
+		return true // WB999
+	}
+
+	var result []int
+	for i := 1; i < len(props); i++ {
+		if isBreak(i) {
+			result = append(result, offsets[i])
+		}
+	}
+	if len(props) > 0 {
+		result = append(result, len(s))
+	}
+	return result
+}
+
+func wordPropOf(r rune) wordProp {
+	switch r {
+	case '\r':
+		return wbCR
+	case '\n':
+		return wbLF
+	case 0x0B, 0x0C, 0x85, 0x2028, 0x2029:
+		return wbNewline
+	case 0x200D:
+		return wbZWJ
+	case 0x200C:
+		return wbExtend
+	case '\'':
+		return wbSingleQuote
+	case '"':
+		return wbDoubleQuote
+	case '.', 0x2018, 0x2019, 0x2024, 0xFE52, 0xFF07, 0xFF0E:
+		return wbMidNumLet
+	case ':', 0x00B7, 0x0387, 0x055F, 0x05F4, 0x2027, 0xFE13, 0xFE55, 0xFF1A:
+		return wbMidLetter
+	case ',', ';', 0x037E, 0x0589, 0x060C, 0x060D, 0x066C, 0x07F8, 0x2044, 0xFE10, 0xFE14, 0xFE50, 0xFE54, 0xFF0C, 0xFF1B:
+		return wbMidNum
+	case 0x202F:
+		return wbExtendNumLet
+	case 0x00A0, 0x2007:
+		return wbOther
+	case 0x3031, 0x3032, 0x3033, 0x3034, 0x3035, 0x309B, 0x309C, 0x30A0, 0x30FC, 0xFF70:
+		return wbKatakana
+	}
+
+	switch {
+	case r < 0x80:
+		switch {
+		case r >= '0' && r <= '9':
+			return wbNumeric
+		case isASCIILetter(byte(r)):
+			return wbALetter
+		case r == '_':
+			return wbExtendNumLet
+		case r == ' ':
+			return wbWSegSpace
+		}
+		return wbOther
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return wbRegionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF, unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Other_Grapheme_Extend):
+		return wbExtend
+	case unicode.Is(unicode.Cf, r):
+		if r == 0x200B {
+			return wbOther
+		}
+		return wbFormat
+	case unicode.Is(unicode.Katakana, r):
+		return wbKatakana
+	case unicode.Is(unicode.Hebrew, r) && unicode.IsLetter(r):
+		return wbHebrewLetter
+	case unicode.Is(unicode.Nd, r):
+		return wbNumeric
+	case unicode.Is(unicode.Pc, r):
+		return wbExtendNumLet
+	case unicode.Is(unicode.Zs, r):
+		return wbWSegSpace
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Thai, unicode.Lao, unicode.Myanmar, unicode.Khmer):
+		return wbOther // ideographs and scripts without spaces between words
+	case unicode.IsLetter(r), unicode.Is(unicode.Nl, r):
+		return wbALetter
+	case unicode.Is(extendedPictographic, r):
+		return wbExtPict
+	}
+	return wbOther
+}
@@ -0,0 +1,103 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordSegments(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"simple", "hello world", []string{"hello", " ", "world"}},
+		{"punctuation", "Hello, world!", []string{"Hello", ",", " ", "world", "!"}},
+		{"spaces run", "a   b", []string{"a", "   ", "b"}},
+		{"apostrophe", "can't stop", []string{"can't", " ", "stop"}},
+		{"decimal number", "pi is 3.14", []string{"pi", " ", "is", " ", "3.14"}},
+		{"thousands separator", "1,000,000 items", []string{"1,000,000", " ", "items"}},
+		{"trailing dot", "end.", []string{"end", "."}},
+		{"hyphen splits", "e-mail", []string{"e", "-", "mail"}},
+		{"underscore joins", "snake_case_name", []string{"snake_case_name"}},
+		{"letters and digits", "utf8 abc123", []string{"utf8", " ", "abc123"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+		{"newlines", "a\n\nb", []string{"a", "\n", "\n", "b"}},
+		{"chinese ideographs", "你好世界", []string{"你", "好", "世", "界"}},
+		{"hiragana", "こんにちは", []string{"こ", "ん", "に", "ち", "は"}},
+		{"katakana joined", "カタカナ テスト", []string{"カタカナ", " ", "テスト"}},
+		{"cyrillic", "привет, мир", []string{"привет", ",", " ", "мир"}},
+		{"combining marks stay in word", "cafe\u0301 au lait", []string{"cafe\u0301", " ", "au", " ", "lait"}},
+		{"hebrew with geresh", "צה\"ל", []string{"צה\"ל"}},
+		{"emoji zwj sequence", "👨‍👩‍👧 family", []string{"👨‍👩‍👧", " ", "family"}},
+		{"flags", "🇺🇸🇬🇧", []string{"🇺🇸", "🇬🇧"}},
+		{"format char ignored", "ab\u00adcd", []string{"ab\u00adcd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WordSegments(tt.s)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.s, strings.Join(got, ""))
+		})
+	}
+}
+
+func TestUnicodeWords(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"punctuation dropped", "Hello, world! How are you?", []string{"Hello", "world", "How", "are", "you"}},
+		{"cjk", "我爱Go语言", []string{"我", "爱", "Go", "语", "言"}},
+		{"emoji and symbols only", "👋 -- !!", nil},
+		{"numbers", "version 1.2.3, build 42", []string{"version", "1.2.3", "build", "42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, UnicodeWords(tt.s))
+		})
+	}
+}
+
+func TestTruncateWordsUnicode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxWords int
+		want     string
+	}{
+		{"no truncation needed", "hello world", 2, "hello world"},
+		{"truncation needed", "hello beautiful world", 2, "hello beautiful..."},
+		{"zero max words", "hello", 0, ""},
+		{"whitespace preserved", "hello    beautiful\nworld", 2, "hello    beautiful..."},
+		{"newlines preserved", "line one\nline two\nline three", 3, "line one\nline..."},
+		{"punctuation between words kept", "Hello, world! How are you?", 2, "Hello, world..."},
+		{"trailing punctuation doesn't count", "one, two, three.", 3, "one, two, three."},
+		{"cjk counted per ideograph", "我爱北京天安门", 3, "我爱北..."},
+		{"mixed", "Go语言很好", 2, "Go语..."},
+		{"emoji not a word", "👋 hello 🌍 world ✨", 2, "👋 hello 🌍 world ✨"},
+		{"emoji between words kept", "👋 hello 🌍 world and more", 2, "👋 hello 🌍 world..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TruncateWordsUnicode(tt.input, tt.maxWords))
+		})
+	}
+}
+
+func TestTruncateWith_UnicodeWords(t *testing.T) {
+	s := "one, two; three. four! five"
+	assert.Equal(t, "...four! five", TruncateWith(s, TruncateOptions{MaxLen: 2, Unit: UnitUnicodeWords, Position: TruncateStart}))
+	assert.Equal(t, "one, two…five", TruncateWith(s, TruncateOptions{MaxLen: 3, Unit: UnitUnicodeWords,
+		Position: TruncateMiddle, Ellipsis: "…"}))
+	assert.Equal(t, "one...", TruncateWith(s, TruncateOptions{MaxLen: 1, Unit: UnitUnicodeWords, Position: TruncateMiddle}))
+	assert.Equal(t, "one, two", TruncateWith(s, TruncateOptions{MaxLen: 2, Unit: UnitUnicodeWords, NoEllipsis: true}))
+}